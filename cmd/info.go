@@ -36,6 +36,7 @@ func printInfo(*cobra.Command, []string) error {
 	printTuple(fmat, "Logs", config.AppLogFile, color.Cyan)
 	printTuple(fmat, "DatabaseConfig", config.AppDatabaseConfigFile, color.Cyan)
 	printTuple(fmat, "RedisConfig", config.AppRedisConfigFile, color.Cyan)
+	printTuple(fmat, "DockerConfig", config.AppDockerConfigFile, color.Cyan)
 
 	return nil
 }
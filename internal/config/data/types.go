@@ -20,4 +20,5 @@ const (
 	MainConfigFile        = "config.yaml"
 	AppDatabaseConfigFile = "app_database_config.yaml" // 数据库应用的配置文件名称
 	AppRedisConfigFile    = "app_redis_config.yaml"    // Redis应用的配置文件名称
+	AppDockerConfigFile   = "app_docker_config.yaml"   // Docker应用的配置文件名称
 )
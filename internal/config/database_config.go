@@ -16,30 +16,56 @@ import (
 )
 
 const (
-	DatabaseProviderMySQL = "MySQL"
+	DatabaseProviderMySQL      = "MySQL"
+	DatabaseProviderPostgreSQL = "PostgreSQL"
+	DatabaseProviderSQLite     = "SQLite"
 )
 
 var DatabaseProviderList = []string{
 	DatabaseProviderMySQL,
+	DatabaseProviderPostgreSQL,
+	DatabaseProviderSQLite,
+}
+
+// DatabaseProviderDefaultPort 各数据库默认端口
+var DatabaseProviderDefaultPort = map[string]int64{
+	DatabaseProviderMySQL:      3306,
+	DatabaseProviderPostgreSQL: 5432,
 }
 
 type DBConnection struct {
-	Name      string   `yaml:"name"      json:"name"`
-	URL       string   `yaml:"url"       json:"url"`
-	Provider  string   `yaml:"provider"  json:"provider"`
-	UserName  string   `yaml:"username"  json:"username"`
-	Password  string   `yaml:"password"  json:"password"`
-	Host      string   `yaml:"host"      json:"host"`
-	Port      int64    `yaml:"port"      json:"port"`
-	DBName    string   `yaml:"dbname"    json:"dbname"`
-	URLParams string   `yaml:"urlParams" json:"urlParams"`
-	Commands  []string `yaml:"commands"  json:"commands"`
+	Name      string    `yaml:"name"      json:"name"`
+	URL       string    `yaml:"url"       json:"url"`
+	Provider  string    `yaml:"provider"  json:"provider"`
+	UserName  string    `yaml:"username"  json:"username"`
+	Password  string    `yaml:"password"  json:"password"`
+	Host      string    `yaml:"host"      json:"host"`
+	Port      int64     `yaml:"port"      json:"port"`
+	DBName    string    `yaml:"dbname"    json:"dbname"`
+	URLParams string    `yaml:"urlParams" json:"urlParams"`
+	Commands  []string  `yaml:"commands"  json:"commands"`
+	FilePath  string    `yaml:"filePath"  json:"filePath"` // SQLite 数据库文件路径
+	TLS       TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+	SSHHost   string    `yaml:"sshHost,omitempty" json:"sshHost,omitempty"` // 经由 ~/.ssh/config 中的主机别名建立隧道
 }
 
 func (d *DBConnection) GetUniqKey() string {
 	return fmt.Sprintf("%s@%s", d.Provider, d.Name)
 }
 
+// GetConnKey 缓存驱动时使用的 key，文件型数据库按文件路径区分，同名的不同文件不会共用一个连接
+func (d *DBConnection) GetConnKey() string {
+	if d.IsFileBased() {
+		return fmt.Sprintf("%s@%s", d.Provider, d.FilePath)
+	}
+	return d.GetUniqKey()
+}
+
+// IsFileBased 是否是基于本地文件的数据库，此类数据库不需要 host/port 等信息
+func (d *DBConnection) IsFileBased() bool {
+	return d.Provider == DatabaseProviderSQLite
+}
+
 type DatabaseConfig struct {
 	DefaultPageSize int             `yaml:"defaultPageSize" json:"defaultPageSize"`
 	DBConnections   []*DBConnection `yaml:"dbConnections"   json:"dbConnections"`
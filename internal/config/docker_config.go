@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"reflect"
+
+	"github.com/liangzhaoliang95/lxz/internal/config/data"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/slogs"
+	"gopkg.in/yaml.v3"
+)
+
+// DockerHostConfig 额外的 Docker 引擎
+type DockerHostConfig struct {
+	Name string `yaml:"name" json:"name"`
+	// 引擎地址 tcp://host:2376、ssh://user@host 或 unix:///var/run/docker.sock
+	Host string    `yaml:"host" json:"host"`
+	TLS  TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+	// ssh:// 地址时远端 Docker 的 socket 路径，为空时为 /var/run/docker.sock
+	Socket string `yaml:"socket,omitempty" json:"socket,omitempty"`
+}
+
+// MaxDockerRunTemplates 保留最近使用的运行参数数量
+const MaxDockerRunTemplates = 10
+
+// DockerRunTemplate 最近使用的运行容器参数
+type DockerRunTemplate struct {
+	Image         string   `yaml:"image" json:"image"`
+	Name          string   `yaml:"name,omitempty" json:"name,omitempty"`
+	Command       string   `yaml:"command,omitempty" json:"command,omitempty"`
+	Env           []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Ports         []string `yaml:"ports,omitempty" json:"ports,omitempty"`
+	Volumes       []string `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+	Network       string   `yaml:"network,omitempty" json:"network,omitempty"`
+	RestartPolicy string   `yaml:"restartPolicy,omitempty" json:"restartPolicy,omitempty"`
+	AutoRemove    bool     `yaml:"autoRemove,omitempty" json:"autoRemove,omitempty"`
+}
+
+// Title 模板列表中展示的名称
+func (t *DockerRunTemplate) Title() string {
+	if t.Name == "" {
+		return t.Image
+	}
+	return t.Name + " (" + t.Image + ")"
+}
+
+type DockerConfig struct {
+	DockerHosts  []*DockerHostConfig  `yaml:"dockerHosts" json:"dockerHosts"`
+	RunTemplates []*DockerRunTemplate `yaml:"runTemplates,omitempty" json:"runTemplates,omitempty"`
+}
+
+// AddRunTemplate 记录最近使用的运行参数，相同的参数移到最前面，超出数量时丢弃最早的
+func (c *DockerConfig) AddRunTemplate(t *DockerRunTemplate) {
+	templates := []*DockerRunTemplate{t}
+	for _, item := range c.RunTemplates {
+		if !reflect.DeepEqual(item, t) {
+			templates = append(templates, item)
+		}
+	}
+	if len(templates) > MaxDockerRunTemplates {
+		templates = templates[:MaxDockerRunTemplates]
+	}
+	c.RunTemplates = templates
+}
+
+// String()
+func (c *DockerConfig) String() string {
+	return helper.Prettify(c)
+}
+
+// Save docker configuration to disk.
+func (c *DockerConfig) Save(force bool) error {
+	if _, err := os.Stat(AppDockerConfigFile); errors.Is(err, fs.ErrNotExist) {
+		return c.SaveFile(AppDockerConfigFile)
+	}
+	if force {
+		return c.SaveFile(AppDockerConfigFile)
+	}
+
+	return nil
+}
+
+// SaveFile lxz docker configuration to disk.
+func (c *DockerConfig) SaveFile(path string) error {
+	if err := data.EnsureDirPath(path, data.DefaultDirMod); err != nil {
+		return err
+	}
+
+	if err := data.SaveYAML(path, c); err != nil {
+		slog.Error("Unable to save LXZ docker config file", slogs.Error, err)
+		return err
+	}
+
+	slog.Info("[CONFIG] Saving LXZ docker config to disk", slogs.Path, path)
+	return nil
+}
+
+func (c *DockerConfig) Merge(fileRead *DockerConfig) {
+	if len(fileRead.DockerHosts) == 0 {
+		slog.Info("[CONFIG] No docker hosts found in config, using docker contexts only")
+	} else {
+		c.DockerHosts = fileRead.DockerHosts
+	}
+	c.RunTemplates = fileRead.RunTemplates
+}
+
+// Load loads LXZ docker configuration from file.
+func (c *DockerConfig) Load(path string, force bool) error {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if err = c.Save(force); err != nil {
+			return err
+		}
+	}
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var errs error
+
+	var cfg DockerConfig
+	if err = yaml.Unmarshal(bb, &cfg); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("docker config load failed: %w", err))
+	}
+	c.Merge(&cfg)
+
+	return errs
+}
+
+func NewDockerConfig() *DockerConfig {
+	return &DockerConfig{}
+}
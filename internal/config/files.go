@@ -73,6 +73,9 @@ var (
 
 	// AppRedisConfigFile tracks LXZ redis config file.
 	AppRedisConfigFile string
+
+	// AppDockerConfigFile tracks LXZ docker config file.
+	AppDockerConfigFile string
 )
 
 // InitLogLoc initializes LXZ logs location.
@@ -133,6 +136,7 @@ func initLxzEnvLocs() error {
 	AppConfigFile = filepath.Join(AppConfigDir, data.MainConfigFile)
 	AppDatabaseConfigFile = filepath.Join(AppConfigDir, data.AppDatabaseConfigFile)
 	AppRedisConfigFile = filepath.Join(AppConfigDir, data.AppRedisConfigFile)
+	AppDockerConfigFile = filepath.Join(AppConfigDir, data.AppDockerConfigFile)
 	AppHotKeysFile = filepath.Join(AppConfigDir, "hotkeys.yaml")
 	AppAliasesFile = filepath.Join(AppConfigDir, "aliases.yaml")
 	AppPluginsFile = filepath.Join(AppConfigDir, "plugins.yaml")
@@ -187,5 +191,8 @@ func initHomeLocs() error {
 	// Redis配置文件路径
 	AppRedisConfigFile = filepath.Join(AppConfigDir, data.AppRedisConfigFile)
 
+	// Docker配置文件路径
+	AppDockerConfigFile = filepath.Join(AppConfigDir, data.AppDockerConfigFile)
+
 	return nil
 }
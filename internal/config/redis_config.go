@@ -15,12 +15,56 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeCluster    = "cluster"
+	RedisModeSentinel   = "sentinel"
+)
+
+var RedisModeList = []string{
+	RedisModeStandalone,
+	RedisModeCluster,
+	RedisModeSentinel,
+}
+
 type RedisConnConfig struct {
 	Name     string `yaml:"name"     json:"name"`
 	UserName string `yaml:"username" json:"username"`
 	Password string `yaml:"password" json:"password"`
 	Host     string `yaml:"host"     json:"host"`
 	Port     int64  `yaml:"port"     json:"port"`
+	// 连接模式 standalone/cluster/sentinel，为空时为 standalone
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty"`
+	// 集群节点或哨兵节点地址 host:port，为空时使用 Host:Port
+	Addrs []string `yaml:"addrs,omitempty" json:"addrs,omitempty"`
+	// 哨兵模式下的主节点名称
+	MasterName string `yaml:"masterName,omitempty" json:"masterName,omitempty"`
+	// 哨兵节点的密码，与数据节点密码不同
+	SentinelPassword string    `yaml:"sentinelPassword,omitempty" json:"sentinelPassword,omitempty"`
+	TLS              TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+	// 经由 ~/.ssh/config 中的主机别名建立隧道
+	SSHHost string `yaml:"sshHost,omitempty" json:"sshHost,omitempty"`
+}
+
+// GetMode 获取连接模式
+func (c *RedisConnConfig) GetMode() string {
+	if c.Mode == "" {
+		return RedisModeStandalone
+	}
+	return c.Mode
+}
+
+// IsCluster 集群模式没有 db 的概念，只有 db0
+func (c *RedisConnConfig) IsCluster() bool {
+	return c.GetMode() == RedisModeCluster
+}
+
+// SeedAddrs 获取种子节点地址列表
+func (c *RedisConnConfig) SeedAddrs() []string {
+	if len(c.Addrs) > 0 {
+		return c.Addrs
+	}
+	return []string{fmt.Sprintf("%s:%d", c.Host, c.Port)}
 }
 
 type RedisConfig struct {
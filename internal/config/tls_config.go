@@ -0,0 +1,53 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/20 15:10
+ */
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig 数据库和 Redis 连接共用的 TLS 配置
+type TLSConfig struct {
+	Enabled    bool   `yaml:"enabled,omitempty"    json:"enabled,omitempty"`
+	CAFile     string `yaml:"caFile,omitempty"     json:"caFile,omitempty"`     // 服务端证书的 CA 文件
+	CertFile   string `yaml:"certFile,omitempty"   json:"certFile,omitempty"`   // 客户端证书
+	KeyFile    string `yaml:"keyFile,omitempty"    json:"keyFile,omitempty"`    // 客户端私钥
+	SkipVerify bool   `yaml:"skipVerify,omitempty" json:"skipVerify,omitempty"` // 跳过服务端证书校验
+}
+
+// Build 根据配置生成 tls.Config，未启用时返回 nil
+func (c *TLSConfig) Build(serverName string) (*tls.Config, error) {
+	if c == nil || !c.Enabled {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: c.SkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		ca, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificate found in CA file %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
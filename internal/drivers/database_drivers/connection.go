@@ -6,10 +6,20 @@
 package database_drivers
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 
+	"github.com/glebarez/sqlite"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/ssh_drivers"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -22,38 +32,107 @@ type DatabaseConn struct {
 	dbConn *gorm.DB
 }
 
+func (_this *DatabaseConn) config() *config.DBConnection {
+	return _this.cfg
+}
+
 func (_this *DatabaseConn) InitConnect() error {
 	if _this.dbConn != nil {
 		return nil
 	}
+	db, err := _this.openDB(_this.cfg.DBName)
+	if err != nil {
+		return err
+	}
+	_this.dbConn = db
+	return nil
+}
+
+// openDB 按数据库名打开一个新的连接，PostgreSQL 等一个连接只能访问一个库的驱动会用到
+func (_this *DatabaseConn) openDB(dbName string) (*gorm.DB, error) {
 	var dialector gorm.Dialector
-	if _this.cfg.Provider == config.DatabaseProviderMySQL {
-		if _this.cfg.DBName != "" {
-			_this.cfg.URL = fmt.Sprintf(
-				"%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=true&loc=Local",
-				_this.cfg.UserName,
-				_this.cfg.Password,
-				_this.cfg.Host,
-				_this.cfg.Port,
-				_this.cfg.DBName,
-			)
-		} else {
-			_this.cfg.URL = fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8mb4&parseTime=true&loc=Local",
-				_this.cfg.UserName,
-				_this.cfg.Password,
-				_this.cfg.Host,
-				_this.cfg.Port)
+	switch _this.cfg.Provider {
+	case config.DatabaseProviderMySQL:
+		network := "tcp"
+		if _this.cfg.SSHHost != "" {
+			// 注册经由 SSH 隧道的网络类型，DSN 中通过名称引用
+			alias := _this.cfg.SSHHost
+			network = "lxz-ssh-" + alias
+			mysqlDriver.RegisterDialContext(network, func(ctx context.Context, addr string) (net.Conn, error) {
+				return ssh_drivers.Dial(ctx, alias, "tcp", addr)
+			})
+		}
+		_this.cfg.URL = mysqlDSN(_this.cfg, network, dbName)
+		tlsConfig, err := _this.cfg.TLS.Build(_this.cfg.Host)
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			// 按连接注册 TLS 配置，DSN 中通过名称引用
+			tlsName := "lxz-" + _this.cfg.Name
+			if err := mysqlDriver.RegisterTLSConfig(tlsName, tlsConfig); err != nil {
+				return nil, fmt.Errorf("failed to register tls config: %w", err)
+			}
+			_this.cfg.URL += "&tls=" + url.QueryEscape(tlsName)
 		}
 		dialector = mysql.Open(_this.cfg.URL)
+	case config.DatabaseProviderPostgreSQL:
+		if dbName == "" {
+			dbName = defaultPostgresDB
+		}
+		_this.cfg.URL = fmt.Sprintf(
+			"host=%s port=%d user=%s password=%s dbname=%s %s",
+			_this.cfg.Host,
+			_this.cfg.Port,
+			quoteDSNValue(_this.cfg.UserName),
+			quoteDSNValue(_this.cfg.Password),
+			quoteDSNValue(dbName),
+			postgresSSLParams(&_this.cfg.TLS),
+		)
+		if _this.cfg.SSHHost == "" {
+			dialector = postgres.Open(_this.cfg.URL)
+			break
+		}
+		pgConfig, err := pgx.ParseConfig(_this.cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse postgres dsn: %w", err)
+		}
+		alias := _this.cfg.SSHHost
+		pgConfig.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return ssh_drivers.Dial(ctx, alias, network, addr)
+		}
+		dialector = postgres.New(postgres.Config{Conn: stdlib.OpenDB(*pgConfig)})
+	case config.DatabaseProviderSQLite:
+		// 不存在的文件 sqlite 会自动创建，这里只允许打开已有文件
+		if _, err := os.Stat(_this.cfg.FilePath); err != nil {
+			return nil, fmt.Errorf("failed to open sqlite file: %w", err)
+		}
+		_this.cfg.URL = _this.cfg.FilePath
+		dialector = sqlite.Open(_this.cfg.URL)
+	default:
+		return nil, fmt.Errorf("unsupported database provider: %s", _this.cfg.Provider)
 	}
 	db, err := gorm.Open(dialector, &gorm.Config{
 		SkipDefaultTransaction: true,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	_this.dbConn = db
-	return nil
+	return db, nil
+}
+
+// mysqlDSN MySQL 的连接串，network 为 tcp 或注册的 SSH 隧道网络类型
+// clientFoundRows 使 UPDATE 返回匹配的行数而不是实际修改的行数，值未变化的更新不会被当作未匹配到行
+func mysqlDSN(cfg *config.DBConnection, network, dbName string) string {
+	return fmt.Sprintf(
+		"%s:%s@%s(%s:%d)/%s?charset=utf8mb4&parseTime=true&loc=Local&clientFoundRows=true",
+		cfg.UserName,
+		cfg.Password,
+		network,
+		cfg.Host,
+		cfg.Port,
+		dbName,
+	)
 }
 
 func (_this *DatabaseConn) CloseConnect() error {
@@ -69,6 +148,6 @@ func (_this *DatabaseConn) CloseConnect() error {
 		return fmt.Errorf("failed to close database connection: %w", err)
 	}
 	_this.dbConn = nil
-	connMap.Delete(_this.cfg.GetUniqKey())
+	connMap.Delete(_this.cfg.GetConnKey())
 	return nil
 }
@@ -0,0 +1,28 @@
+package database_drivers
+
+import (
+	"testing"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	dsn := mysqlDSN(&config.DBConnection{
+		UserName: "root",
+		Password: "p@ss",
+		Host:     "127.0.0.1",
+		Port:     3306,
+	}, "tcp", "app")
+	cfg, err := mysqlDriver.ParseDSN(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.User != "root" || cfg.Passwd != "p@ss" || cfg.Addr != "127.0.0.1:3306" || cfg.DBName != "app" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	// 值未变化的 UPDATE 按匹配的行计数，不会被当作行已被删除而回滚
+	if !cfg.ClientFoundRows {
+		t.Fatal("expected clientFoundRows to be enabled")
+	}
+}
@@ -0,0 +1,226 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/28 14:00
+ */
+
+package database_drivers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type RowChangeKind int
+
+const (
+	RowUpdate RowChangeKind = iota
+	RowInsert
+	RowDelete
+)
+
+// ColumnValue 列及其值，Value 为 nil 时表示 NULL
+type ColumnValue struct {
+	Column string
+	Value  *string
+}
+
+// RowChange 表格中对一行的修改
+type RowChange struct {
+	Kind   RowChangeKind
+	Key    []ColumnValue // 主键的原值，UPDATE 和 DELETE 按主键定位行
+	Values []ColumnValue // UPDATE 修改的列，INSERT 写入的列，未写入的列使用默认值
+}
+
+// Statement 参数化的 DML 语句，Preview 为参数替换成字面量后的语句，只用于展示
+type Statement struct {
+	Query   string
+	Args    []any
+	Preview string
+}
+
+// dmlDialect 不同数据库生成 DML 时的差异
+type dmlDialect struct {
+	quote         func(name string) string
+	placeholder   func(n int) string    // n 从 1 开始
+	defaultValues string                // 所有列都使用默认值时的 INSERT 写法
+	literal       func(v string) string // 字符串字面量的写法
+}
+
+var (
+	mysqlDialect = dmlDialect{
+		quote: func(name string) string {
+			return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+		},
+		placeholder:   func(int) string { return "?" },
+		defaultValues: "() VALUES ()",
+		literal: func(v string) string {
+			return "'" + mysqlLiteralReplacer.Replace(v) + "'"
+		},
+	}
+	sqliteDialect = dmlDialect{
+		quote:         quoteIdent,
+		placeholder:   func(int) string { return "?" },
+		defaultValues: "DEFAULT VALUES",
+		literal:       quoteLiteral,
+	}
+	postgresDialect = dmlDialect{
+		quote:         quoteIdent,
+		placeholder:   func(n int) string { return fmt.Sprintf("$%d", n) },
+		defaultValues: "DEFAULT VALUES",
+		literal:       quoteLiteral,
+	}
+)
+
+// mysqlLiteralReplacer MySQL 默认将反斜杠视为转义符
+var mysqlLiteralReplacer = strings.NewReplacer(`\`, `\\`, "'", "''")
+
+// quoteLiteral 按 SQL 标准将字符串转换为字面量
+func quoteLiteral(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// stmtBuilder 同时拼接参数化语句和预览语句
+type stmtBuilder struct {
+	d       dmlDialect
+	query   strings.Builder
+	preview strings.Builder
+	args    []any
+}
+
+func (b *stmtBuilder) text(s string) {
+	b.query.WriteString(s)
+	b.preview.WriteString(s)
+}
+
+func (b *stmtBuilder) value(v *string) {
+	if v == nil {
+		b.text("NULL")
+		return
+	}
+	b.args = append(b.args, *v)
+	b.query.WriteString(b.d.placeholder(len(b.args)))
+	b.preview.WriteString(b.d.literal(*v))
+}
+
+// where 按主键定位行，值为 NULL 时使用 IS NULL
+func (b *stmtBuilder) where(key []ColumnValue) {
+	b.text(" WHERE ")
+	for i, k := range key {
+		if i > 0 {
+			b.text(" AND ")
+		}
+		b.text(b.d.quote(k.Column))
+		if k.Value == nil {
+			b.text(" IS NULL")
+			continue
+		}
+		b.text(" = ")
+		b.value(k.Value)
+	}
+}
+
+func (b *stmtBuilder) statement() Statement {
+	return Statement{Query: b.query.String(), Args: b.args, Preview: b.preview.String()}
+}
+
+// buildStatements 将表格中的修改转换为 DML 语句，table 为已转义的表名
+func buildStatements(d dmlDialect, table string, changes []RowChange) ([]Statement, error) {
+	statements := make([]Statement, 0, len(changes))
+	for _, c := range changes {
+		b := &stmtBuilder{d: d}
+		switch c.Kind {
+		case RowUpdate:
+			if len(c.Values) == 0 {
+				continue
+			}
+			if len(c.Key) == 0 {
+				return nil, errors.New("primary key is required to update a row")
+			}
+			b.text("UPDATE " + table + " SET ")
+			for i, v := range c.Values {
+				if i > 0 {
+					b.text(", ")
+				}
+				b.text(d.quote(v.Column) + " = ")
+				b.value(v.Value)
+			}
+			b.where(c.Key)
+		case RowInsert:
+			b.text("INSERT INTO " + table + " ")
+			if len(c.Values) == 0 {
+				b.text(d.defaultValues)
+				break
+			}
+			columns := make([]string, 0, len(c.Values))
+			for _, v := range c.Values {
+				columns = append(columns, d.quote(v.Column))
+			}
+			b.text("(" + strings.Join(columns, ", ") + ") VALUES (")
+			for i, v := range c.Values {
+				if i > 0 {
+					b.text(", ")
+				}
+				b.value(v.Value)
+			}
+			b.text(")")
+		case RowDelete:
+			if len(c.Key) == 0 {
+				return nil, errors.New("primary key is required to delete a row")
+			}
+			b.text("DELETE FROM " + table)
+			b.where(c.Key)
+		default:
+			return nil, fmt.Errorf("unknown row change kind: %d", c.Kind)
+		}
+		statements = append(statements, b.statement())
+	}
+	return statements, nil
+}
+
+// execInTransaction 在一个事务中执行语句，任一语句失败或按主键未匹配到行时整体回滚
+// 影响行数需按匹配的行计算，MySQL 的连接串中开启了 clientFoundRows
+func execInTransaction(sqlDB *sql.DB, statements []Statement) error {
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	for i, s := range statements {
+		result, err := tx.Exec(s.Query, s.Args...)
+		if err == nil && !strings.HasPrefix(s.Query, "INSERT") {
+			var affected int64
+			if affected, err = result.RowsAffected(); err == nil && affected == 0 {
+				err = errors.New("no row matched, it may have been changed or deleted")
+			}
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("statement %d failed, all changes rolled back: %w", i+1, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// queryStrings 查询单列的结果
+func queryStrings(sqlDB *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := sqlDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var list []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
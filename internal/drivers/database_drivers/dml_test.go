@@ -0,0 +1,125 @@
+package database_drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestBuildStatements(t *testing.T) {
+	changes := []RowChange{
+		{
+			Kind:   RowUpdate,
+			Key:    []ColumnValue{{Column: "id", Value: strPtr("1")}},
+			Values: []ColumnValue{{Column: "name", Value: strPtr("O'Brien")}, {Column: "note", Value: nil}},
+		},
+		{Kind: RowUpdate, Key: []ColumnValue{{Column: "id", Value: strPtr("2")}}},
+		{Kind: RowInsert, Values: []ColumnValue{{Column: "name", Value: strPtr("new")}}},
+		{Kind: RowInsert},
+		{Kind: RowDelete, Key: []ColumnValue{{Column: "id", Value: strPtr("3")}, {Column: "tenant", Value: nil}}},
+	}
+
+	got, err := buildStatements(postgresDialect, `"public"."users"`, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Statement{
+		{
+			Query:   `UPDATE "public"."users" SET "name" = $1, "note" = NULL WHERE "id" = $2`,
+			Args:    []any{"O'Brien", "1"},
+			Preview: `UPDATE "public"."users" SET "name" = 'O''Brien', "note" = NULL WHERE "id" = '1'`,
+		},
+		{
+			Query:   `INSERT INTO "public"."users" ("name") VALUES ($1)`,
+			Args:    []any{"new"},
+			Preview: `INSERT INTO "public"."users" ("name") VALUES ('new')`,
+		},
+		{
+			Query:   `INSERT INTO "public"."users" DEFAULT VALUES`,
+			Preview: `INSERT INTO "public"."users" DEFAULT VALUES`,
+		},
+		{
+			Query:   `DELETE FROM "public"."users" WHERE "id" = $1 AND "tenant" IS NULL`,
+			Args:    []any{"3"},
+			Preview: `DELETE FROM "public"."users" WHERE "id" = '3' AND "tenant" IS NULL`,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v\nwant %#v", got, want)
+	}
+
+	got, err = buildStatements(mysqlDialect, "`db`.`users`", changes[3:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Query != "INSERT INTO `db`.`users` () VALUES ()" || got[1].Query != "DELETE FROM `db`.`users` WHERE `id` = ? AND `tenant` IS NULL" {
+		t.Fatalf("unexpected mysql statements %#v", got)
+	}
+
+	if _, err = buildStatements(mysqlDialect, "t", []RowChange{{Kind: RowDelete}}); err == nil {
+		t.Fatal("expected error for delete without primary key")
+	}
+}
+
+func TestSQLiteStatements(t *testing.T) {
+	driver := newSQLiteTestDriver(t, "CREATE TABLE items (a INTEGER, b TEXT, name TEXT DEFAULT 'x', PRIMARY KEY (b, a))")
+
+	keys, err := driver.GetPrimaryKeys("", "items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, []string{"b", "a"}) {
+		t.Fatalf("unexpected primary keys %v", keys)
+	}
+
+	statements, err := driver.BuildStatements("", "items", []RowChange{
+		{Kind: RowInsert, Values: []ColumnValue{{Column: "a", Value: strPtr("1")}, {Column: "b", Value: strPtr("k")}}},
+		{Kind: RowInsert, Values: []ColumnValue{{Column: "a", Value: strPtr("2")}, {Column: "b", Value: strPtr("k")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = driver.ExecuteStatements("", statements); err != nil {
+		t.Fatal(err)
+	}
+
+	statements, err = driver.BuildStatements("", "items", []RowChange{
+		{
+			Kind:   RowUpdate,
+			Key:    []ColumnValue{{Column: "b", Value: strPtr("k")}, {Column: "a", Value: strPtr("1")}},
+			Values: []ColumnValue{{Column: "name", Value: strPtr("updated")}},
+		},
+		{Kind: RowDelete, Key: []ColumnValue{{Column: "b", Value: strPtr("k")}, {Column: "a", Value: strPtr("2")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = driver.ExecuteStatements("", statements); err != nil {
+		t.Fatal(err)
+	}
+	records, total, err := driver.GetRecords("", "items", "", "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || !reflect.DeepEqual(records[1], []string{"1", "k", "updated"}) {
+		t.Fatalf("unexpected records %v", records)
+	}
+
+	// 未匹配到行时整个事务回滚
+	statements, err = driver.BuildStatements("", "items", []RowChange{
+		{Kind: RowInsert, Values: []ColumnValue{{Column: "a", Value: strPtr("3")}, {Column: "b", Value: strPtr("k")}}},
+		{Kind: RowDelete, Key: []ColumnValue{{Column: "b", Value: strPtr("missing")}, {Column: "a", Value: strPtr("1")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = driver.ExecuteStatements("", statements); err == nil {
+		t.Fatal("expected error when no row matched")
+	}
+	if _, total, _ = driver.GetRecords("", "items", "", "", 0, 0); total != 1 {
+		t.Fatalf("expected rollback, got %d rows", total)
+	}
+}
@@ -1,15 +1,23 @@
 package database_drivers
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/liangzhaoliang95/lxz/internal/config"
 )
 
 const (
 	DefaultRowLimit = 100
+
+	// schemaPathSep 数据库与 schema 之间的分隔符
+	schemaPathSep = "/"
 )
 
 var connMap sync.Map
@@ -19,7 +27,43 @@ type IDatabaseConn interface {
 	GetDbList() ([]string, error)
 	GetTableList(dbName string) ([]string, error)
 	GetRecords(database, table, where, sort string, offset, limit int) ([][]string, int, error)
-	ExecuteQuery(query string) ([][]string, int, error)
+	// ExecuteQuery 在指定的库中执行查询，database 与 GetRecords 相同，为空时使用连接的默认库
+	ExecuteQuery(database, query string) ([][]string, int, error)
+	// GetPrimaryKeys 获取表的主键列，按主键定义中的顺序返回，没有主键时返回空
+	GetPrimaryKeys(database, table string) ([]string, error)
+	// BuildStatements 将表格中的修改转换为 DML 语句，用于预览和提交
+	BuildStatements(database, table string, changes []RowChange) ([]Statement, error)
+	// ExecuteStatements 在一个事务中执行语句
+	ExecuteStatements(database string, statements []Statement) error
+	// GetTableStructure 获取表的列、索引、外键、触发器和建表语句
+	GetTableStructure(database, table string) (*TableStructure, error)
+	// StreamRecords 按过滤条件和排序逐行读取整张表，不分页，用于导出
+	StreamRecords(database, table, where, sort string, w RowWriter) error
+	// StreamQuery 逐行读取查询的完整结果，用于导出
+	StreamQuery(database, query string, w RowWriter) error
+}
+
+// ISchemaDatabaseConn 数据库下还有 schema 层级的驱动（如 PostgreSQL）需要实现该接口
+// 此时 IDatabaseConn 中的 database 参数使用 SchemaPath 组合后的路径
+type ISchemaDatabaseConn interface {
+	GetSchemaList(dbName string) ([]string, error)
+}
+
+// SchemaPath 将数据库名和 schema 组合成驱动可识别的路径
+func SchemaPath(dbName, schema string) string {
+	if schema == "" {
+		return dbName
+	}
+	return dbName + schemaPathSep + schema
+}
+
+// SplitSchemaPath 拆分 SchemaPath 组合的路径
+func SplitSchemaPath(path string) (dbName, schema string) {
+	idx := strings.LastIndex(path, schemaPathSep)
+	if idx < 0 {
+		return path, ""
+	}
+	return path[:idx], path[idx+1:]
 }
 
 // ---helpers
@@ -34,6 +78,20 @@ func _initDriver(cfg *config.DBConnection) (IDatabaseConn, error) {
 				dbConn: nil,
 			},
 		}
+	case config.DatabaseProviderPostgreSQL:
+		dbDriver = &PostgreSQLDriver{
+			DatabaseConn: &DatabaseConn{
+				cfg:    cfg,
+				dbConn: nil,
+			},
+		}
+	case config.DatabaseProviderSQLite:
+		dbDriver = &SQLiteDriver{
+			DatabaseConn: &DatabaseConn{
+				cfg:    cfg,
+				dbConn: nil,
+			},
+		}
 	default:
 		return nil, fmt.Errorf("unsupported database provider: %s", cfg.Provider)
 	}
@@ -41,10 +99,10 @@ func _initDriver(cfg *config.DBConnection) (IDatabaseConn, error) {
 }
 
 func GetConnect(cfg *config.DBConnection) (IDatabaseConn, error) {
-	if db, exists := connMap.Load(cfg.GetUniqKey()); exists {
+	if db, exists := connMap.Load(cfg.GetConnKey()); exists {
 		return db.(IDatabaseConn), nil
 	}
-	return nil, fmt.Errorf("database connection not found for key: %s", cfg.GetUniqKey())
+	return nil, fmt.Errorf("database connection not found for key: %s", cfg.GetConnKey())
 }
 
 func GetConnectOrInit(cfg *config.DBConnection) (IDatabaseConn, error) {
@@ -54,7 +112,7 @@ func GetConnectOrInit(cfg *config.DBConnection) (IDatabaseConn, error) {
 		}
 	}()
 
-	key := cfg.GetUniqKey()
+	key := cfg.GetConnKey()
 	if db, exists := connMap.Load(key); exists {
 		if conn, ok := db.(IDatabaseConn); ok {
 			return conn, nil
@@ -71,6 +129,24 @@ func GetConnectOrInit(cfg *config.DBConnection) (IDatabaseConn, error) {
 	return GetConnect(cfg)
 }
 
+// ResetConnect 关闭并移除使用该配置的驱动，配置修改后下次使用时按新配置重新连接
+// 编辑连接时配置是原地修改的，名称可能已经变化，因此按配置对象而不是 key 查找
+func ResetConnect(cfg *config.DBConnection) {
+	connMap.Range(func(key, value any) bool {
+		conn, ok := value.(interface {
+			config() *config.DBConnection
+			CloseConnect() error
+		})
+		if ok && conn.config() == cfg {
+			if err := conn.CloseConnect(); err != nil {
+				slog.Warn("Failed to close database connection", "key", key, "error", err)
+			}
+			connMap.Delete(key)
+		}
+		return true
+	})
+}
+
 func TestConnection(cfg *config.DBConnection) error {
 	if cfg == nil {
 		return fmt.Errorf("database connection configuration is nil")
@@ -96,3 +172,134 @@ func TestConnection(cfg *config.DBConnection) error {
 	}
 	return nil
 }
+
+// OrderBy 生成 GetRecords 的排序参数，按数据库类型转义列名，column 为空时不排序
+func OrderBy(provider, column string, desc bool) string {
+	if column == "" {
+		return ""
+	}
+	quoted := dialectOf(provider).quote(column)
+	if desc {
+		return quoted + " DESC"
+	}
+	return quoted + " ASC"
+}
+
+// dialectOf 数据库类型对应的 SQL 方言
+func dialectOf(provider string) dmlDialect {
+	switch provider {
+	case config.DatabaseProviderMySQL:
+		return mysqlDialect
+	case config.DatabaseProviderPostgreSQL:
+		return postgresDialect
+	default:
+		return sqliteDialect
+	}
+}
+
+// PageCount 按每页行数计算总页数，没有数据时也至少有一页
+func PageCount(total, pageSize int) int {
+	if pageSize <= 0 {
+		pageSize = DefaultRowLimit
+	}
+	if total <= 0 {
+		return 1
+	}
+	return (total + pageSize - 1) / pageSize
+}
+
+// quoteIdent 按 SQL 标准（PostgreSQL、SQLite）转义标识符
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// queryIn 执行查询并处理结果，use 为切换库或 schema 的语句
+// use 不为空时在单独的连接上执行，用完后丢弃该连接，避免切换后的连接回到连接池中影响其他查询
+func queryIn(sqlDB *sql.DB, use, query string, fn func(rows *sql.Rows) error) error {
+	if use == "" {
+		rows, err := sqlDB.Query(query)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		return fn(rows)
+	}
+
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// 返回 driver.ErrBadConn 时连接池会关闭该连接
+		_ = conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+		_ = conn.Close()
+	}()
+	if _, err = conn.ExecContext(ctx, use); err != nil {
+		return err
+	}
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return fn(rows)
+}
+
+// scanRows 将查询结果转换成字符串表格，第一行为列名
+func scanRows(rows *sql.Rows) ([][]string, int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := make([][]string, 0)
+	for rows.Next() {
+		rowValues := make([]any, len(columns))
+		for i := range rowValues {
+			rowValues[i] = new(any)
+		}
+		if err = rows.Scan(rowValues...); err != nil {
+			return nil, 0, err
+		}
+
+		row := make([]string, 0, len(columns))
+		for _, col := range rowValues {
+			row = append(row, formatValue(*col.(*any)))
+		}
+		records = append(records, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return append([][]string{columns}, records...), len(records), nil
+}
+
+// formatValue 将驱动返回的值格式化为表格展示的文本，空值和空串使用与 MySQL 一致的占位符
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL&"
+	case []byte:
+		if len(val) == 0 {
+			return "EMPTY&"
+		}
+		return string(val)
+	case string:
+		if val == "" {
+			return "EMPTY&"
+		}
+		return val
+	case time.Time:
+		return val.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,127 @@
+package database_drivers
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+)
+
+func TestOrderBy(t *testing.T) {
+	cases := []struct {
+		provider, column string
+		desc             bool
+		want             string
+	}{
+		{config.DatabaseProviderMySQL, "", false, ""},
+		{config.DatabaseProviderMySQL, "id", false, "`id` ASC"},
+		{config.DatabaseProviderMySQL, "we`ird", true, "`we``ird` DESC"},
+		{config.DatabaseProviderPostgreSQL, "created_at", true, `"created_at" DESC`},
+		{config.DatabaseProviderSQLite, `a"b`, false, `"a""b" ASC`},
+	}
+	for _, c := range cases {
+		if got := OrderBy(c.provider, c.column, c.desc); got != c.want {
+			t.Errorf("OrderBy(%s, %q, %v) = %q, want %q", c.provider, c.column, c.desc, got, c.want)
+		}
+	}
+}
+
+func TestPageCount(t *testing.T) {
+	cases := []struct{ total, size, want int }{
+		{0, 100, 1},
+		{1, 100, 1},
+		{100, 100, 1},
+		{101, 100, 2},
+		{250, 0, 3},
+	}
+	for _, c := range cases {
+		if got := PageCount(c.total, c.size); got != c.want {
+			t.Errorf("PageCount(%d, %d) = %d, want %d", c.total, c.size, got, c.want)
+		}
+	}
+}
+
+func TestQueryIn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	// 临时表只在创建它的连接上可见，用来模拟切换库后的连接状态
+	var n int
+	err = queryIn(sqlDB, "CREATE TEMP TABLE t AS SELECT 7 AS n", "SELECT n FROM t", func(rows *sql.Rows) error {
+		rows.Next()
+		return rows.Scan(&n)
+	})
+	if err != nil || n != 7 {
+		t.Fatalf("got %d, err %v", n, err)
+	}
+
+	// 切换过的连接已被丢弃，不会回到连接池中
+	err = queryIn(sqlDB, "", "SELECT n FROM temp.t", func(rows *sql.Rows) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the temp table to be gone with its connection")
+	}
+}
+
+func TestGetConnectOrInitFileBased(t *testing.T) {
+	// 不同目录下的同名文件不能共用缓存的驱动
+	var drivers []IDatabaseConn
+	for _, dir := range []string{t.TempDir(), t.TempDir()} {
+		cfg := &config.DBConnection{
+			Name:     "app.db",
+			Provider: config.DatabaseProviderSQLite,
+			FilePath: filepath.Join(dir, "app.db"),
+		}
+		conn, err := GetConnectOrInit(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			connMap.Delete(cfg.GetConnKey())
+		})
+		drivers = append(drivers, conn)
+	}
+	if drivers[0] == drivers[1] {
+		t.Fatal("expected a separate driver for each file")
+	}
+}
+
+func TestResetConnect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.db")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.DBConnection{
+		Name:     "app",
+		Provider: config.DatabaseProviderSQLite,
+		FilePath: path,
+	}
+	conn, err := GetConnectOrInit(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.GetDbList(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 编辑时配置被原地修改，旧的 key 已经无法计算
+	cfg.FilePath = filepath.Join(t.TempDir(), "other.db")
+	ResetConnect(cfg)
+	connMap.Range(func(key, _ any) bool {
+		t.Errorf("expected no cached driver, found %v", key)
+		return true
+	})
+	if conn.(*SQLiteDriver).dbConn != nil {
+		t.Fatal("expected the old connection to be closed")
+	}
+}
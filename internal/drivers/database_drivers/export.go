@@ -0,0 +1,316 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/30 10:00
+ */
+
+package database_drivers
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// 导出格式
+const (
+	ExportCSV      = "CSV"
+	ExportJSON     = "JSON Lines"
+	ExportMarkdown = "Markdown"
+	ExportSQL      = "SQL INSERT"
+)
+
+var ExportFormatList = []string{ExportCSV, ExportJSON, ExportMarkdown, ExportSQL}
+
+// exportTimeLayout 导出时间的格式，保留小数秒和时区，导出的 SQL 可以原样导入
+const exportTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// ExportExt 导出格式对应的文件扩展名
+func ExportExt(format string) string {
+	switch format {
+	case ExportJSON:
+		return ".jsonl"
+	case ExportMarkdown:
+		return ".md"
+	case ExportSQL:
+		return ".sql"
+	default:
+		return ".csv"
+	}
+}
+
+// RowWriter 逐行接收查询结果，先调用一次 WriteHeader，值为 nil 时表示 NULL
+type RowWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []*string) error
+}
+
+// Exporter 将查询结果写成指定的格式，写完后需调用 Flush
+type Exporter interface {
+	RowWriter
+	Flush() error
+}
+
+// NewExporter 创建导出器，table 和 provider 只用于 SQL INSERT 格式
+func NewExporter(format, provider, table string, w io.Writer) (Exporter, error) {
+	switch format {
+	case ExportCSV:
+		return &csvExporter{w: csv.NewWriter(w)}, nil
+	case ExportJSON:
+		return &jsonExporter{w: bufio.NewWriter(w)}, nil
+	case ExportMarkdown:
+		return &markdownExporter{w: bufio.NewWriter(w)}, nil
+	case ExportSQL:
+		if table == "" {
+			return nil, fmt.Errorf("table name is required for %s", ExportSQL)
+		}
+		return &sqlExporter{w: bufio.NewWriter(w), d: dialectOf(provider), table: table}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// ExportRecords 将表格中展示的数据写入 RowWriter，第一行为列名，NULL& 和 EMPTY& 还原为原值
+func ExportRecords(w RowWriter, records [][]string) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := w.WriteHeader(records[0]); err != nil {
+		return err
+	}
+	for _, record := range records[1:] {
+		values := make([]*string, len(record))
+		for i, cell := range record {
+			switch cell {
+			case "NULL&":
+			case "EMPTY&":
+				values[i] = new(string)
+			default:
+				values[i] = &record[i]
+			}
+		}
+		if err := w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamQuery 执行查询并将结果逐行写入 RowWriter
+func streamQuery(sqlDB *sql.DB, query string, w RowWriter) error {
+	rows, err := sqlDB.Query(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return streamRows(rows, w)
+}
+
+// selectAll 导出整张表的查询语句，tableName 为已转义的表名
+func selectAll(tableName, where, sort string) string {
+	query := "SELECT * FROM " + tableName
+	if where != "" {
+		query += fmt.Sprintf(" %s", where)
+	}
+	if sort != "" {
+		query += fmt.Sprintf(" ORDER BY %s", sort)
+	}
+	return query
+}
+
+// streamRows 将查询结果逐行写入 RowWriter，不在内存中保留整个结果
+func streamRows(rows *sql.Rows, w RowWriter) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if err = w.WriteHeader(columns); err != nil {
+		return err
+	}
+	rowValues := make([]any, len(columns))
+	for i := range rowValues {
+		rowValues[i] = new(any)
+	}
+	for rows.Next() {
+		if err = rows.Scan(rowValues...); err != nil {
+			return err
+		}
+		values := make([]*string, len(columns))
+		for i, col := range rowValues {
+			values[i] = rawValue(*col.(*any))
+		}
+		if err = w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// rawValue 将驱动返回的值转换为导出的文本，与 formatValue 不同，空值和空串不使用占位符，时间不截断
+func rawValue(v any) *string {
+	var s string
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		s = string(val)
+	case string:
+		s = val
+	case time.Time:
+		s = val.Format(exportTimeLayout)
+	default:
+		s = fmt.Sprintf("%v", val)
+	}
+	return &s
+}
+
+// csvExporter NULL 与空串都写为空字段
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func (_this *csvExporter) WriteHeader(columns []string) error {
+	return _this.w.Write(columns)
+}
+
+func (_this *csvExporter) WriteRow(values []*string) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		if v != nil {
+			record[i] = *v
+		}
+	}
+	return _this.w.Write(record)
+}
+
+func (_this *csvExporter) Flush() error {
+	_this.w.Flush()
+	return _this.w.Error()
+}
+
+// jsonExporter 每行一个 JSON 对象，按列的顺序输出，值均为字符串或 null
+type jsonExporter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (_this *jsonExporter) WriteHeader(columns []string) error {
+	_this.columns = make([]string, len(columns))
+	for i, c := range columns {
+		key, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		_this.columns[i] = string(key)
+	}
+	return nil
+}
+
+func (_this *jsonExporter) WriteRow(values []*string) error {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(_this.columns[i] + ":")
+		if v == nil {
+			b.WriteString("null")
+			continue
+		}
+		value, err := json.Marshal(*v)
+		if err != nil {
+			return err
+		}
+		b.Write(value)
+	}
+	b.WriteString("}\n")
+	_, err := _this.w.WriteString(b.String())
+	return err
+}
+
+func (_this *jsonExporter) Flush() error {
+	return _this.w.Flush()
+}
+
+// markdownExporter 输出 Markdown 表格，NULL 写为 NULL
+type markdownExporter struct {
+	w *bufio.Writer
+}
+
+var markdownReplacer = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func (_this *markdownExporter) writeLine(cells []string) error {
+	_, err := _this.w.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	return err
+}
+
+func (_this *markdownExporter) WriteHeader(columns []string) error {
+	header := make([]string, len(columns))
+	separator := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = markdownReplacer.Replace(c)
+		separator[i] = "---"
+	}
+	if err := _this.writeLine(header); err != nil {
+		return err
+	}
+	return _this.writeLine(separator)
+}
+
+func (_this *markdownExporter) WriteRow(values []*string) error {
+	cells := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			cells[i] = "NULL"
+			continue
+		}
+		cells[i] = markdownReplacer.Replace(*v)
+	}
+	return _this.writeLine(cells)
+}
+
+func (_this *markdownExporter) Flush() error {
+	return _this.w.Flush()
+}
+
+// sqlExporter 每行一条 INSERT 语句，表名和列名按数据库类型转义
+type sqlExporter struct {
+	w      *bufio.Writer
+	d      dmlDialect
+	table  string
+	prefix string // INSERT INTO table (columns) VALUES
+}
+
+func (_this *sqlExporter) WriteHeader(columns []string) error {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = _this.d.quote(c)
+	}
+	_this.prefix = fmt.Sprintf("INSERT INTO %s (%s) VALUES ",
+		_this.d.quote(_this.table), strings.Join(quoted, ", "))
+	return nil
+}
+
+func (_this *sqlExporter) WriteRow(values []*string) error {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			literals[i] = "NULL"
+			continue
+		}
+		literals[i] = _this.d.literal(*v)
+	}
+	_, err := _this.w.WriteString(_this.prefix + "(" + strings.Join(literals, ", ") + ");\n")
+	return err
+}
+
+func (_this *sqlExporter) Flush() error {
+	return _this.w.Flush()
+}
@@ -0,0 +1,103 @@
+package database_drivers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+)
+
+func TestExportRecords(t *testing.T) {
+	records := [][]string{
+		{"id", "name", "note"},
+		{"1", "O'Brien|\"x\"", "NULL&"},
+		{"2", "EMPTY&", "line1\nline2 \\"},
+	}
+	tests := []struct {
+		format   string
+		provider string
+		want     string
+	}{
+		{ExportCSV, "", "id,name,note\n1,\"O'Brien|\"\"x\"\"\",\n2,,\"line1\nline2 \\\"\n"},
+		{ExportJSON, "", `{"id":"1","name":"O'Brien|\"x\"","note":null}` + "\n" +
+			`{"id":"2","name":"","note":"line1\nline2 \\"}` + "\n"},
+		{ExportMarkdown, "", "| id | name | note |\n| --- | --- | --- |\n" +
+			"| 1 | O'Brien\\|\"x\" | NULL |\n| 2 |  | line1<br>line2 \\\\ |\n"},
+		{ExportSQL, config.DatabaseProviderPostgreSQL,
+			`INSERT INTO "users" ("id", "name", "note") VALUES ('1', 'O''Brien|"x"', NULL);` + "\n" +
+				`INSERT INTO "users" ("id", "name", "note") VALUES ('2', '', 'line1` + "\n" + `line2 \');` + "\n"},
+		{ExportSQL, config.DatabaseProviderMySQL,
+			"INSERT INTO `users` (`id`, `name`, `note`) VALUES ('1', 'O''Brien|\"x\"', NULL);\n" +
+				"INSERT INTO `users` (`id`, `name`, `note`) VALUES ('2', '', 'line1\nline2 \\\\');\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		e, err := NewExporter(tt.format, tt.provider, "users", &b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ExportRecords(e, records); err != nil {
+			t.Fatal(err)
+		}
+		if err = e.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s %s: got\n%s\nwant\n%s", tt.format, tt.provider, b.String(), tt.want)
+		}
+	}
+
+	if _, err := NewExporter(ExportSQL, "", "", &strings.Builder{}); err == nil {
+		t.Fatal("expected an error without table name")
+	}
+}
+
+func TestRawValue(t *testing.T) {
+	cases := []struct {
+		v    any
+		want string
+	}{
+		{time.Date(2025, 8, 24, 7, 10, 11, 123456000, time.FixedZone("", 8*3600)), "2025-08-24 07:10:11.123456+08:00"},
+		{time.Date(2025, 8, 24, 7, 10, 11, 0, time.UTC), "2025-08-24 07:10:11+00:00"},
+		{[]byte("a"), "a"},
+		{int64(3), "3"},
+	}
+	for _, c := range cases {
+		if got := rawValue(c.v); got == nil || *got != c.want {
+			t.Errorf("rawValue(%v) = %v, want %q", c.v, got, c.want)
+		}
+	}
+	if got := rawValue(nil); got != nil {
+		t.Errorf("rawValue(nil) = %q, want nil", *got)
+	}
+}
+
+func TestSQLiteStreamRecords(t *testing.T) {
+	driver := newSQLiteTestDriver(t,
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO users (name) VALUES ('a'), (NULL), (''), ('d')",
+	)
+
+	// 不受分页大小限制，按过滤条件和排序输出
+	var b strings.Builder
+	e, _ := NewExporter(ExportJSON, "", "", &b)
+	if err := driver.StreamRecords("", "users", "WHERE id > 1", OrderBy(config.DatabaseProviderSQLite, "id", true), e); err != nil {
+		t.Fatal(err)
+	}
+	_ = e.Flush()
+	want := `{"id":"4","name":"d"}` + "\n" + `{"id":"3","name":""}` + "\n" + `{"id":"2","name":null}` + "\n"
+	if b.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	e, _ = NewExporter(ExportCSV, "", "", &b)
+	if err := driver.StreamQuery("", "SELECT count(*) AS n FROM users", e); err != nil {
+		t.Fatal(err)
+	}
+	_ = e.Flush()
+	if want = "n\n4\n"; b.String() != want {
+		t.Fatalf("got %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,622 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/31 10:00
+ */
+
+package database_drivers
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 导入文件的格式
+const (
+	ImportCSV  = "CSV"
+	ImportJSON = "JSON"
+)
+
+var ImportFormatList = []string{ImportCSV, ImportJSON}
+
+// 主键或唯一键重复时的处理方式
+const (
+	ImportFail   = "Fail"
+	ImportSkip   = "Skip"
+	ImportUpdate = "Update"
+)
+
+var ImportDuplicateList = []string{ImportFail, ImportSkip, ImportUpdate}
+
+const (
+	DefaultImportBatchSize = 500
+	// ImportSampleRows 预览时读取的行数，JSON 文件的列名也由这些行确定
+	ImportSampleRows = 100
+	// maxImportErrors 报告中最多保留的错误数
+	maxImportErrors = 100
+	// maxPlaceholders 一条语句中最多的参数个数
+	maxPlaceholders = 65535
+
+	utf8BOM = "\ufeff"
+)
+
+// IImportDatabaseConn 支持从文件导入数据的驱动（目前只有 MySQL）需要实现该接口
+type IImportDatabaseConn interface {
+	// ImportRows 在一个事务中分批写入，出错时回滚，每写完一批调用一次 progress
+	ImportRows(database, table string, r ImportReader, opts ImportOptions, progress func(report ImportReport)) (*ImportReport, error)
+}
+
+// ImportOptions 导入的列映射和写入方式
+type ImportOptions struct {
+	Columns       []string // 写入的目标列，未映射的列使用默认值
+	Sources       []int    // 目标列对应的文件中的列的下标
+	BatchSize     int      // 每条 INSERT 写入的行数
+	OnDuplicate   string   // ImportDuplicateList 中的一种
+	EmptyAsNull   bool     // 空串写为 NULL
+	SkipMalformed bool     // 跳过格式错误的行并记录到报告中，否则遇到时停止导入
+}
+
+// ImportError 文件中无法导入的行
+type ImportError struct {
+	Line int // CSV 为行号，JSON 为第几个对象
+	Err  string
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// ImportReport 导入的结果
+type ImportReport struct {
+	Rows       int           // 已写入数据库的行数，不含格式错误的行
+	Affected   int           // 数据库返回的影响行数，Update 时更新的行计为 2 行，Skip 时为插入的行数
+	Skipped    int           // Skip 时因重复跳过的行数
+	ErrorCount int           // 格式错误的行数
+	Errors     []ImportError // 格式错误的行，最多保留 maxImportErrors 条
+}
+
+func (r *ImportReport) addError(e *ImportError) {
+	r.ErrorCount++
+	if len(r.Errors) < maxImportErrors {
+		r.Errors = append(r.Errors, *e)
+	}
+}
+
+// ImportReader 逐行读取导入文件，读完时返回 io.EOF
+// 返回 *ImportError 时表示该行格式错误，可以继续读取下一行
+type ImportReader interface {
+	Columns() []string
+	// Next 返回下一行的值和行号，值为 nil 时表示 NULL
+	Next() (values []*string, line int, err error)
+}
+
+// ImportFormatOf 按扩展名判断导入文件的格式
+func ImportFormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl", ".ndjson":
+		return ImportJSON
+	default:
+		return ImportCSV
+	}
+}
+
+// ImportFile 打开的导入文件，记录已读取的字节数用于计算进度
+type ImportFile struct {
+	ImportReader
+	file *os.File
+	size int64
+	read *countingReader
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (_this *countingReader) Read(p []byte) (int, error) {
+	n, err := _this.r.Read(p)
+	_this.n += int64(n)
+	return n, err
+}
+
+// OpenImportFile 打开导入文件，使用完后需调用 Close
+func OpenImportFile(path, format string) (*ImportFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	f := &ImportFile{file: file, size: info.Size(), read: &countingReader{r: file}}
+	switch format {
+	case ImportJSON:
+		f.ImportReader, err = newJSONImportReader(f.read)
+	default:
+		f.ImportReader, err = newCSVImportReader(f.read)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Progress 已读取的比例，读取有缓冲，会略快于实际写入的进度
+func (_this *ImportFile) Progress() float64 {
+	if _this.size == 0 {
+		return 1
+	}
+	return min(float64(_this.read.n)/float64(_this.size), 1)
+}
+
+func (_this *ImportFile) Close() error {
+	return _this.file.Close()
+}
+
+// ImportSample 导入文件的列名和前几行，用于列映射和类型预览
+type ImportSample struct {
+	Columns []string
+	Rows    [][]*string
+	Errors  []ImportError
+}
+
+// ReadImportSample 读取导入文件的前 n 行
+func ReadImportSample(path, format string, n int) (*ImportSample, error) {
+	f, err := OpenImportFile(path, format)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	s := &ImportSample{Columns: f.Columns()}
+	for len(s.Rows) < n {
+		values, _, err := f.Next()
+		var rowErr *ImportError
+		switch {
+		case errors.Is(err, io.EOF):
+			return s, nil
+		case errors.As(err, &rowErr):
+			s.Errors = append(s.Errors, *rowErr)
+		case err != nil:
+			return nil, err
+		default:
+			s.Rows = append(s.Rows, values)
+		}
+	}
+	return s, nil
+}
+
+// Values 第 i 列的示例值
+func (_this *ImportSample) Values(i int) []*string {
+	values := make([]*string, 0, len(_this.Rows))
+	for _, row := range _this.Rows {
+		if i < len(row) {
+			values = append(values, row[i])
+		}
+	}
+	return values
+}
+
+// csvImportReader 第一行为列名
+type csvImportReader struct {
+	r       *csv.Reader
+	columns []string
+}
+
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	cr := csv.NewReader(bufio.NewReader(r))
+	// 列数由表头决定，在 Next 中检查
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Excel 导出的 CSV 带有 BOM
+	header[0] = strings.TrimPrefix(header[0], utf8BOM)
+	return &csvImportReader{r: cr, columns: header}, nil
+}
+
+func (_this *csvImportReader) Columns() []string {
+	return _this.columns
+}
+
+func (_this *csvImportReader) Next() ([]*string, int, error) {
+	record, err := _this.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, parseErr.StartLine, &ImportError{Line: parseErr.StartLine, Err: parseErr.Err.Error()}
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := _this.r.FieldPos(0)
+	if len(record) != len(_this.columns) {
+		return nil, line, &ImportError{
+			Line: line,
+			Err:  fmt.Sprintf("expected %d fields, got %d", len(_this.columns), len(record)),
+		}
+	}
+	values := make([]*string, len(record))
+	for i := range record {
+		values[i] = &record[i]
+	}
+	return values, line, nil
+}
+
+// jsonImportReader 支持 JSON 数组和每行一个对象的 JSON Lines，列名为前 ImportSampleRows 个对象中出现的键
+type jsonImportReader struct {
+	dec      *json.Decoder
+	array    bool
+	columns  []string
+	buffered []jsonImportRow // 确定列名时已读取的对象
+	count    int             // 已读取的对象数
+}
+
+type jsonImportRow struct {
+	line   int
+	values map[string]*string
+	err    error
+}
+
+func newJSONImportReader(r io.Reader) (*jsonImportReader, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(len(utf8BOM)); string(bom) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	first, err := peekNonSpace(br)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	jr := &jsonImportReader{dec: json.NewDecoder(br), array: first == '['}
+	if jr.array {
+		if _, err = jr.dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	seen := make(map[string]bool)
+	for len(jr.buffered) < ImportSampleRows {
+		keys, row, err := jr.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row.err == nil && err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				jr.columns = append(jr.columns, key)
+			}
+		}
+		jr.buffered = append(jr.buffered, row)
+	}
+	if len(jr.columns) == 0 {
+		return nil, errors.New("no JSON object found")
+	}
+	return jr, nil
+}
+
+// peekNonSpace 跳过开头的空白，返回第一个字符但不读取它
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+// read 读取下一个对象，格式错误的对象通过 row.err 返回
+func (_this *jsonImportReader) read() ([]string, jsonImportRow, error) {
+	if _this.array && !_this.dec.More() {
+		return nil, jsonImportRow{}, io.EOF
+	}
+	var raw json.RawMessage
+	if err := _this.dec.Decode(&raw); err != nil {
+		return nil, jsonImportRow{}, err
+	}
+	_this.count++
+	row := jsonImportRow{line: _this.count}
+	keys, values, err := decodeJSONObject(raw)
+	if err != nil {
+		row.err = &ImportError{Line: _this.count, Err: err.Error()}
+		return nil, row, row.err
+	}
+	row.values = values
+	return keys, row, nil
+}
+
+func (_this *jsonImportReader) Columns() []string {
+	return _this.columns
+}
+
+func (_this *jsonImportReader) Next() ([]*string, int, error) {
+	var row jsonImportRow
+	if len(_this.buffered) > 0 {
+		row, _this.buffered = _this.buffered[0], _this.buffered[1:]
+	} else {
+		var err error
+		if _, row, err = _this.read(); row.err == nil && err != nil {
+			return nil, 0, err
+		}
+	}
+	if row.err != nil {
+		return nil, row.line, row.err
+	}
+	values := make([]*string, len(_this.columns))
+	for i, c := range _this.columns {
+		values[i] = row.values[c]
+	}
+	return values, row.line, nil
+}
+
+// decodeJSONObject 按键出现的顺序解析对象，布尔值转换为 1 和 0，嵌套的对象和数组保留原文
+func decodeJSONObject(raw json.RawMessage) ([]string, map[string]*string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, errors.New("not a JSON object")
+	}
+	var keys []string
+	values := make(map[string]*string)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := tok.(string)
+		var v json.RawMessage
+		if err = dec.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = jsonValue(v)
+	}
+	return keys, values, nil
+}
+
+func jsonValue(v json.RawMessage) *string {
+	s := string(v)
+	switch {
+	case s == "null":
+		return nil
+	case s == "true":
+		s = "1"
+	case s == "false":
+		s = "0"
+	case strings.HasPrefix(s, `"`):
+		_ = json.Unmarshal(v, &s)
+	}
+	return &s
+}
+
+// InferColumnType 根据示例值推断列的类型，只用于预览
+func InferColumnType(values []*string) string {
+	var (
+		count  int
+		maxLen int
+		isInt  = true
+		isNum  = true
+		isDate = true
+		isTime = true
+	)
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		count++
+		maxLen = max(maxLen, len([]rune(*v)))
+		if _, err := strconv.ParseInt(*v, 10, 64); err != nil {
+			isInt = false
+		}
+		if _, err := strconv.ParseFloat(*v, 64); err != nil {
+			isNum = false
+		}
+		_, err := time.Parse(time.DateOnly, *v)
+		if err != nil {
+			isDate = false
+		}
+		// 日期和日期时间混合时推断为日期时间
+		if err != nil && !isDateTime(*v) {
+			isTime = false
+		}
+	}
+	switch {
+	case count == 0:
+		return "NULL"
+	case isInt:
+		return "INT"
+	case isNum:
+		return "DECIMAL"
+	case isDate:
+		return "DATE"
+	case isTime:
+		return "DATETIME"
+	case maxLen <= 255:
+		return fmt.Sprintf("VARCHAR(%d)", maxLen)
+	default:
+		return "TEXT"
+	}
+}
+
+func isDateTime(v string) bool {
+	for _, layout := range []string{time.DateTime, time.RFC3339, time.RFC3339Nano} {
+		if _, err := time.Parse(layout, v); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportTypeCompatible 推断的类型能否写入目标列，无法判断时视为兼容
+func ImportTypeCompatible(inferred, columnType string) bool {
+	t := strings.ToLower(columnType)
+	if inferred == "NULL" {
+		return true
+	}
+	hasPrefix := func(prefixes ...string) bool {
+		return slices.ContainsFunc(prefixes, func(p string) bool {
+			return strings.HasPrefix(t, p)
+		})
+	}
+	switch {
+	case hasPrefix("tinyint", "smallint", "mediumint", "int", "bigint", "bit", "year"):
+		return inferred == "INT"
+	case hasPrefix("decimal", "numeric", "float", "double", "real"):
+		return inferred == "INT" || inferred == "DECIMAL"
+	case t == "date":
+		return inferred == "DATE"
+	case strings.HasPrefix(t, "datetime") || strings.HasPrefix(t, "timestamp"):
+		return inferred == "DATE" || inferred == "DATETIME"
+	}
+	return true
+}
+
+// mysqlImportQuery 一批 rows 行的 INSERT 语句，table 为已转义的表名
+// 跳过重复时把 keyColumn 更新为自身，而不是 INSERT IGNORE，其它数据错误仍会报错
+// keyColumn 为空（表没有主键，只有唯一索引）时使用第一列
+func mysqlImportQuery(table string, columns []string, rows int, onDuplicate, keyColumn string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = mysqlDialect.quote(c)
+	}
+	group := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, strings.Join(quoted, ", "),
+		strings.TrimSuffix(strings.Repeat(group+", ", rows), ", "))
+	switch onDuplicate {
+	case ImportSkip:
+		key := quoted[0]
+		if keyColumn != "" {
+			key = mysqlDialect.quote(keyColumn)
+		}
+		query += fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", key, key)
+	case ImportUpdate:
+		updates := make([]string, len(quoted))
+		for i, c := range quoted {
+			updates[i] = fmt.Sprintf("%s = VALUES(%s)", c, c)
+		}
+		query += " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+	}
+	return query
+}
+
+// countRows 统计事务中可见的表的行数，table 为已转义的表名
+func countRows(tx *sql.Tx, table string) (int, error) {
+	var n int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return n, nil
+}
+
+// importRows 按映射读取文件中的行并分批写入，buildQuery 生成 rows 行的 INSERT 语句
+// 未设置 SkipMalformed 时遇到格式错误的行立即停止，否则跳过并记录到报告中
+func importRows(
+	tx *sql.Tx,
+	buildQuery func(rows int) string,
+	r ImportReader,
+	opts ImportOptions,
+	progress func(report ImportReport),
+) (*ImportReport, error) {
+	report := &ImportReport{}
+	if len(opts.Columns) == 0 || len(opts.Columns) != len(opts.Sources) {
+		return report, errors.New("map at least one column")
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	batchSize = min(batchSize, maxPlaceholders/len(opts.Columns))
+
+	var (
+		args      = make([]any, 0, batchSize*len(opts.Columns))
+		rows      int
+		firstLine int
+		lastLine  int
+	)
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		result, err := tx.Exec(buildQuery(rows), args...)
+		if err != nil {
+			return fmt.Errorf("lines %d-%d: %w", firstLine, lastLine, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		report.Rows += rows
+		report.Affected += int(affected)
+		args, rows = args[:0], 0
+		if progress != nil {
+			progress(*report)
+		}
+		return nil
+	}
+
+	for {
+		values, line, err := r.Next()
+		var rowErr *ImportError
+		switch {
+		case errors.Is(err, io.EOF):
+			return report, flush()
+		case errors.As(err, &rowErr):
+			if !opts.SkipMalformed {
+				return report, rowErr
+			}
+			report.addError(rowErr)
+			continue
+		case err != nil:
+			return report, err
+		}
+		for _, src := range opts.Sources {
+			var v *string
+			if src >= 0 && src < len(values) {
+				v = values[src]
+			}
+			if v != nil && *v == "" && opts.EmptyAsNull {
+				v = nil
+			}
+			if v == nil {
+				args = append(args, nil)
+			} else {
+				args = append(args, *v)
+			}
+		}
+		if rows == 0 {
+			firstLine = line
+		}
+		rows, lastLine = rows+1, line
+		if rows == batchSize {
+			if err = flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+}
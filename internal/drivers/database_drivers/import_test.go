@@ -0,0 +1,219 @@
+package database_drivers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readAll 读取所有行，NULL 用 <nil> 表示
+func readAll(t *testing.T, r ImportReader) ([][]string, []int) {
+	t.Helper()
+	var (
+		rows     [][]string
+		errLines []int
+	)
+	for {
+		values, line, err := r.Next()
+		var rowErr *ImportError
+		switch {
+		case err == io.EOF:
+			return rows, errLines
+		case errors.As(err, &rowErr):
+			errLines = append(errLines, line)
+			continue
+		case err != nil:
+			t.Fatal(err)
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = "<nil>"
+			if v != nil {
+				row[i] = *v
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVImportReader(t *testing.T) {
+	r, err := newCSVImportReader(strings.NewReader("\ufeffid,name\n1,\"a,b\"\n2\n3,\"multi\nline\"\n4,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"id", "name"}; !reflect.DeepEqual(r.Columns(), want) {
+		t.Fatalf("got columns %v, want %v", r.Columns(), want)
+	}
+	rows, lines := readAll(t, r)
+	wantRows := [][]string{{"1", "a,b"}, {"3", "multi\nline"}, {"4", ""}}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Fatalf("got rows %q, want %q", rows, wantRows)
+	}
+	if want := []int{3}; !reflect.DeepEqual(lines, want) {
+		t.Fatalf("got error lines %v, want %v", lines, want)
+	}
+}
+
+func TestJSONImportReader(t *testing.T) {
+	for _, input := range []string{
+		`[{"id": 1, "name": "a", "ok": true}, 5, {"id": 2, "tags": ["x"], "ok": false, "name": null}]`,
+		"{\"id\": 1, \"name\": \"a\", \"ok\": true}\n5\n{\"id\": 2, \"tags\": [\"x\"], \"ok\": false, \"name\": null}\n",
+	} {
+		r, err := newJSONImportReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"id", "name", "ok", "tags"}; !reflect.DeepEqual(r.Columns(), want) {
+			t.Fatalf("got columns %v, want %v", r.Columns(), want)
+		}
+		rows, lines := readAll(t, r)
+		wantRows := [][]string{{"1", "a", "1", "<nil>"}, {"2", "<nil>", "0", `["x"]`}}
+		if !reflect.DeepEqual(rows, wantRows) {
+			t.Fatalf("got rows %q, want %q", rows, wantRows)
+		}
+		if want := []int{2}; !reflect.DeepEqual(lines, want) {
+			t.Fatalf("got error lines %v, want %v", lines, want)
+		}
+	}
+
+	if _, err := newJSONImportReader(strings.NewReader("  ")); err == nil {
+		t.Fatal("expected an error for an empty file")
+	}
+}
+
+func TestInferColumnType(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "-20"}, "INT"},
+		{[]string{"1", "2.5"}, "DECIMAL"},
+		{[]string{"2025-08-31"}, "DATE"},
+		{[]string{"2025-08-31", "2025-08-31 10:00:00"}, "DATETIME"},
+		{[]string{"abc", "中文"}, "VARCHAR(3)"},
+		{[]string{strings.Repeat("x", 256)}, "TEXT"},
+		{nil, "NULL"},
+	}
+	for _, tt := range tests {
+		values := []*string{nil}
+		for i := range tt.values {
+			values = append(values, &tt.values[i])
+		}
+		if got := InferColumnType(values); got != tt.want {
+			t.Errorf("InferColumnType(%v) = %s, want %s", tt.values, got, tt.want)
+		}
+	}
+
+	if !ImportTypeCompatible("INT", "bigint unsigned") || ImportTypeCompatible("DECIMAL", "int") ||
+		!ImportTypeCompatible("DATE", "datetime(6)") || ImportTypeCompatible("VARCHAR(3)", "date") ||
+		!ImportTypeCompatible("DATETIME", "varchar(20)") || !ImportTypeCompatible("NULL", "int") ||
+		!ImportTypeCompatible("VARCHAR(10)", "point") {
+		t.Fatal("unexpected type compatibility")
+	}
+}
+
+func TestMySQLImportQuery(t *testing.T) {
+	columns := []string{"id", "name"}
+	tests := []struct {
+		onDuplicate string
+		want        string
+	}{
+		{ImportFail, "INSERT INTO `db`.`t` (`id`, `name`) VALUES (?, ?), (?, ?)"},
+		{ImportSkip, "INSERT INTO `db`.`t` (`id`, `name`) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE `uid` = `uid`"},
+		{ImportUpdate, "INSERT INTO `db`.`t` (`id`, `name`) VALUES (?, ?), (?, ?)" +
+			" ON DUPLICATE KEY UPDATE `id` = VALUES(`id`), `name` = VALUES(`name`)"},
+	}
+	for _, tt := range tests {
+		if got := mysqlImportQuery("`db`.`t`", columns, 2, tt.onDuplicate, "uid"); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.onDuplicate, got, tt.want)
+		}
+	}
+
+	// 没有主键时用第一列
+	want := "INSERT INTO `t` (`id`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id` = `id`"
+	if got := mysqlImportQuery("`t`", columns, 1, ImportSkip, ""); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestImportRows(t *testing.T) {
+	driver := newSQLiteTestDriver(t, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, note TEXT DEFAULT 'n/a')")
+	sqlDB, err := driver.dbConn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildQuery := func(rows int) string {
+		return "INSERT INTO users (name, id) VALUES " + strings.TrimSuffix(strings.Repeat("(?, ?), ", rows), ", ")
+	}
+	// 文件中的列顺序与表不同，note 未映射使用默认值
+	input := "id,name\n1,a\n2,\n3\n4,d\n5,e\n"
+	importCSV := func(opts ImportOptions) (*ImportReport, error) {
+		r, err := newCSVImportReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var batches int
+		report, err := importRows(tx, buildQuery, r, opts, func(ImportReport) { batches++ })
+		if err != nil {
+			_ = tx.Rollback()
+			return report, err
+		}
+		if want := 2; batches != want {
+			t.Fatalf("got %d batches, want %d", batches, want)
+		}
+		return report, tx.Commit()
+	}
+	opts := ImportOptions{
+		Columns:     []string{"name", "id"},
+		Sources:     []int{1, 0},
+		BatchSize:   2,
+		OnDuplicate: ImportSkip,
+		EmptyAsNull: true,
+	}
+
+	// 未设置 SkipMalformed 时格式错误的行导致回滚，与重复时的处理方式无关
+	if _, err = importCSV(opts); err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("expected a format error on line 4, got %v", err)
+	}
+	var count int
+	if err = sqlDB.QueryRow("SELECT count(*) FROM users").Scan(&count); err != nil || count != 0 {
+		t.Fatalf("expected rollback, got %d rows, err %v", count, err)
+	}
+
+	opts.SkipMalformed = true
+	report, err := importCSV(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows != 4 || report.Affected != 4 || report.ErrorCount != 1 || report.Errors[0].Line != 4 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	var got []string
+	err = queryEach(sqlDB, "SELECT id, coalesce(name, 'NULL'), note FROM users ORDER BY id", nil, func(rows *sql.Rows) error {
+		var id, name, note string
+		if err := rows.Scan(&id, &name, &note); err != nil {
+			return err
+		}
+		got = append(got, id+":"+name+":"+note)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1:a:n/a", "2:NULL:n/a", "4:d:n/a", "5:e:n/a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// 重复的主键报告所在的行
+	opts.OnDuplicate = ImportUpdate
+	if _, err = importCSV(opts); err == nil || !strings.Contains(err.Error(), "lines 2-3") {
+		t.Fatalf("expected a duplicate error on lines 2-3, got %v", err)
+	}
+}
@@ -185,8 +185,10 @@ func (_this *MySQLDriver) GetRecords(
 	}
 
 	countQuery := "SELECT COUNT(*) FROM "
-	countQuery += fmt.Sprintf("`%s`.", database)
-	countQuery += fmt.Sprintf("`%s`", table)
+	countQuery += _this.formatTableName(database, table)
+	if where != "" {
+		countQuery += fmt.Sprintf(" %s", where)
+	}
 	row := sqlDB.QueryRow(countQuery)
 	if err := row.Scan(&totalRecords); err != nil {
 		return nil, 0, err
@@ -195,57 +197,300 @@ func (_this *MySQLDriver) GetRecords(
 	return paginatedResults, totalRecords, nil
 }
 
-func (_this *MySQLDriver) ExecuteQuery(query string) ([][]string, int, error) {
+// GetPrimaryKeys 获取表的主键列
+func (_this *MySQLDriver) GetPrimaryKeys(database, table string) ([]string, error) {
 	if _this.dbConn == nil {
 		err := _this.InitConnect()
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	keys, err := queryStrings(sqlDB, `SELECT COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+ORDER BY ORDINAL_POSITION`, database, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query primary keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (_this *MySQLDriver) BuildStatements(database, table string, changes []RowChange) ([]Statement, error) {
+	return buildStatements(mysqlDialect, _this.formatTableName(database, table), changes)
+}
 
+func (_this *MySQLDriver) ExecuteStatements(database string, statements []Statement) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return err
+		}
+	}
 	sqlDB, err := _this.dbConn.DB()
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
 	}
+	return execInTransaction(sqlDB, statements)
+}
 
-	rows, err := sqlDB.Query(query)
+// GetTableStructure 从 information_schema 获取表结构，建表语句来自 SHOW CREATE TABLE
+func (_this *MySQLDriver) GetTableStructure(database, table string) (*TableStructure, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sqlDB, err := _this.dbConn.DB()
 	if err != nil {
-		return nil, 0, err
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	s := &TableStructure{}
+	args := []any{database, table}
+
+	err = queryEach(sqlDB, `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLUMN_KEY, EXTRA, COLUMN_COMMENT
+FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`, args,
+		func(rows *sql.Rows) error {
+			var c TableColumn
+			var nullable string
+			var def sql.NullString
+			if err := rows.Scan(&c.Name, &c.Type, &nullable, &def, &c.Key, &c.Extra, &c.Comment); err != nil {
+				return err
+			}
+			c.Nullable = nullable == "YES"
+			c.Default = nullString(def)
+			s.Columns = append(s.Columns, c)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
 	}
-	defer func() {
-		_ = rows.Close()
-	}()
 
-	columns, err := rows.Columns()
+	err = queryEach(sqlDB, `SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME, INDEX_TYPE
+FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX`, args,
+		func(rows *sql.Rows) error {
+			var index TableIndex
+			var nonUnique int
+			var column sql.NullString
+			if err := rows.Scan(&index.Name, &nonUnique, &column, &index.Type); err != nil {
+				return err
+			}
+			index.Unique = nonUnique == 0
+			index.Primary = index.Name == "PRIMARY"
+			// 函数索引没有列名
+			if !column.Valid {
+				column.String = "(expression)"
+			}
+			s.Indexes = appendIndexColumn(s.Indexes, index, column.String)
+			return nil
+		})
 	if err != nil {
-		return nil, 0, err
+		return nil, fmt.Errorf("failed to query indexes: %w", err)
 	}
 
-	records := make([][]string, 0)
-	for rows.Next() {
-		rowValues := make([]interface{}, len(columns))
-		for i := range columns {
-			rowValues[i] = new(sql.RawBytes)
+	err = queryEach(sqlDB, `SELECT k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME, r.UPDATE_RULE, r.DELETE_RULE
+FROM information_schema.KEY_COLUMN_USAGE k
+JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+  ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND r.TABLE_NAME = k.TABLE_NAME
+WHERE k.TABLE_SCHEMA = ? AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`, args,
+		func(rows *sql.Rows) error {
+			var fk TableForeignKey
+			var column, refColumn string
+			if err := rows.Scan(&fk.Name, &column, &fk.RefTable, &refColumn, &fk.OnUpdate, &fk.OnDelete); err != nil {
+				return err
+			}
+			s.ForeignKeys = appendForeignKeyColumn(s.ForeignKeys, fk, column, refColumn)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
+	}
+
+	err = queryEach(sqlDB, `SELECT TRIGGER_NAME, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT
+FROM information_schema.TRIGGERS WHERE EVENT_OBJECT_SCHEMA = ? AND EVENT_OBJECT_TABLE = ? ORDER BY TRIGGER_NAME`, args,
+		func(rows *sql.Rows) error {
+			var t TableTrigger
+			if err := rows.Scan(&t.Name, &t.Timing, &t.Event, &t.Statement); err != nil {
+				return err
+			}
+			s.Triggers = appendTriggerEvent(s.Triggers, t)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query triggers: %w", err)
+	}
+
+	// 视图返回的列与表不同，第二列都是建表或建视图的语句
+	ddl, _, err := _this.ExecuteQuery("", "SHOW CREATE TABLE "+_this.formatTableName(database, table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to show create table: %w", err)
+	}
+	if len(ddl) > 1 && len(ddl[1]) > 1 {
+		s.DDL = ddl[1][1]
+	}
+	return s, nil
+}
+
+// ExecuteQuery 在指定的库中执行查询，database 为空或与连接的默认库相同时直接使用连接池
+func (_this *MySQLDriver) ExecuteQuery(database, query string) ([][]string, int, error) {
+	var results [][]string
+	err := _this._queryIn(database, query, func(rows *sql.Rows) error {
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
 		}
 
-		err = rows.Scan(rowValues...)
+		records := make([][]string, 0)
+		for rows.Next() {
+			rowValues := make([]interface{}, len(columns))
+			for i := range columns {
+				rowValues[i] = new(sql.RawBytes)
+			}
+
+			err = rows.Scan(rowValues...)
+			if err != nil {
+				return err
+			}
+
+			var row []string
+			for _, col := range rowValues {
+				row = append(row, string(*col.(*sql.RawBytes)))
+			}
+
+			records = append(records, row)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// Prepend the columns to the records.
+		results = append([][]string{columns}, records...)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, len(results) - 1, nil
+}
+
+// _queryIn 切换到 database 后执行查询
+func (_this *MySQLDriver) _queryIn(database, query string, fn func(rows *sql.Rows) error) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
 		if err != nil {
-			return nil, 0, err
+			return err
 		}
+	}
 
-		var row []string
-		for _, col := range rowValues {
-			row = append(row, string(*col.(*sql.RawBytes)))
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	use := ""
+	if database != "" && database != _this.cfg.DBName {
+		use = "USE " + mysqlDialect.quote(database)
+	}
+	return queryIn(sqlDB, use, query, fn)
+}
+
+// StreamRecords 逐行读取整张表，用于导出
+func (_this *MySQLDriver) StreamRecords(database, table, where, sort string, w RowWriter) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return err
 		}
+	}
+	if table == "" {
+		return errors.New("table name is required")
+	}
+	if database == "" {
+		return errors.New("database name is required")
+	}
 
-		records = append(records, row)
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, 0, err
+
+	query := selectAll(_this.formatTableName(database, table), where, sort)
+	slog.Debug("Streaming query", "query", query)
+	return streamQuery(sqlDB, query, w)
+}
+
+// StreamQuery 逐行读取查询结果，用于导出
+func (_this *MySQLDriver) StreamQuery(database, query string, w RowWriter) error {
+	return _this._queryIn(database, query, func(rows *sql.Rows) error {
+		return streamRows(rows, w)
+	})
+}
+
+// ImportRows 在一个事务中分批导入文件中的行，出错时回滚
+func (_this *MySQLDriver) ImportRows(
+	database, table string,
+	r ImportReader,
+	opts ImportOptions,
+	progress func(report ImportReport),
+) (*ImportReport, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if table == "" {
+		return nil, errors.New("table name is required")
+	}
+	if database == "" {
+		return nil, errors.New("database name is required")
+	}
+
+	var keyColumn string
+	if opts.OnDuplicate == ImportSkip {
+		keys, err := _this.GetPrimaryKeys(database, table)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) > 0 {
+			keyColumn = keys[0]
+		}
 	}
 
-	// Prepend the columns to the records.
-	results := append([][]string{columns}, records...)
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return nil, err
+	}
 
-	return results, len(records), nil
+	tableName := _this.formatTableName(database, table)
+	// 连接开启了 clientFoundRows，跳过的重复行也计入影响行数，Skip 时按导入前后的行数计算插入和跳过的行数
+	var before int
+	if opts.OnDuplicate == ImportSkip {
+		if before, err = countRows(tx, tableName); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+	}
+	report, err := importRows(tx, func(rows int) string {
+		return mysqlImportQuery(tableName, opts.Columns, rows, opts.OnDuplicate, keyColumn)
+	}, r, opts, progress)
+	if err == nil && opts.OnDuplicate == ImportSkip {
+		var after int
+		if after, err = countRows(tx, tableName); err == nil {
+			report.Affected = after - before
+			report.Skipped = report.Rows - report.Affected
+		}
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return report, err
+	}
+	return report, tx.Commit()
 }
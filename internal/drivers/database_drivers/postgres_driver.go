@@ -0,0 +1,578 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/18 10:12
+ */
+
+package database_drivers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPostgresDB     = "postgres"
+	defaultPostgresSchema = "public"
+)
+
+type PostgreSQLDriver struct {
+	*DatabaseConn
+	dbConns sync.Map // PostgreSQL 一个连接只能访问一个库，按库名缓存连接 dbName -> *gorm.DB
+}
+
+// quoteDSNValue 转义 key=value 形式 DSN 中的值
+func quoteDSNValue(v string) string {
+	if v == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+// postgresSSLParams 将 TLS 配置转换为 libpq 的 sslmode 等参数
+func postgresSSLParams(cfg *config.TLSConfig) string {
+	if !cfg.Enabled {
+		return "sslmode=disable"
+	}
+	params := []string{"sslmode=verify-full"}
+	if cfg.SkipVerify {
+		params[0] = "sslmode=require"
+	}
+	if cfg.CAFile != "" && !cfg.SkipVerify {
+		params = append(params, "sslrootcert="+quoteDSNValue(cfg.CAFile))
+	}
+	if cfg.CertFile != "" {
+		params = append(params, "sslcert="+quoteDSNValue(cfg.CertFile))
+	}
+	if cfg.KeyFile != "" {
+		params = append(params, "sslkey="+quoteDSNValue(cfg.KeyFile))
+	}
+	return strings.Join(params, " ")
+}
+
+func (_this *PostgreSQLDriver) formatTableName(schema, table string) string {
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	return fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
+}
+
+func (_this *PostgreSQLDriver) GetDBConn() (*DatabaseConn, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return _this.DatabaseConn, nil
+}
+
+// getDatabase 获取指定库的连接，库名为空或与默认库相同时复用默认连接
+func (_this *PostgreSQLDriver) getDatabase(dbName string) (*gorm.DB, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	defaultDB := _this.cfg.DBName
+	if defaultDB == "" {
+		defaultDB = defaultPostgresDB
+	}
+	if dbName == "" || dbName == defaultDB {
+		return _this.dbConn, nil
+	}
+	if db, ok := _this.dbConns.Load(dbName); ok {
+		return db.(*gorm.DB), nil
+	}
+	db, err := _this.openDB(dbName)
+	if err != nil {
+		return nil, err
+	}
+	_this.dbConns.Store(dbName, db)
+	return db, nil
+}
+
+// GetDbList 获取当前PostgreSQL实例的数据库列表
+func (_this *PostgreSQLDriver) GetDbList() ([]string, error) {
+	db, err := _this.getDatabase("")
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	rows, err := sqlDB.Query(
+		"SELECT datname FROM pg_database WHERE datistemplate = false AND datallowconn ORDER BY datname",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query databases: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var databases []string
+	for rows.Next() {
+		var dbName string
+		if err := rows.Scan(&dbName); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		databases = append(databases, dbName)
+	}
+	return databases, rows.Err()
+}
+
+// GetSchemaList 获取指定库下的 schema 列表，过滤掉系统 schema
+func (_this *PostgreSQLDriver) GetSchemaList(dbName string) ([]string, error) {
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	rows, err := sqlDB.Query(`SELECT schema_name FROM information_schema.schemata
+WHERE schema_name NOT IN ('pg_catalog', 'information_schema')
+  AND schema_name NOT LIKE 'pg\_toast%'
+  AND schema_name NOT LIKE 'pg\_temp\_%'
+ORDER BY schema_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schemas: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, fmt.Errorf("failed to scan schema name: %w", err)
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, rows.Err()
+}
+
+// GetTableList 获取指定 schema 下的表列表，dbName 为 SchemaPath 组合的路径
+func (_this *PostgreSQLDriver) GetTableList(dbName string) ([]string, error) {
+	database, schema := SplitSchemaPath(dbName)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	db, err := _this.getDatabase(database)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	rows, err := sqlDB.Query(
+		"SELECT table_name FROM information_schema.tables WHERE table_schema = $1 ORDER BY table_name",
+		schema,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var tableList []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tableList = append(tableList, tableName)
+	}
+	return tableList, rows.Err()
+}
+
+func (_this *PostgreSQLDriver) GetRecords(
+	database, table, where, sort string,
+	offset, limit int,
+) (paginatedResults [][]string, totalRecords int, err error) {
+	if table == "" {
+		return nil, 0, errors.New("table name is required")
+	}
+
+	if database == "" {
+		return nil, 0, errors.New("database name is required")
+	}
+
+	if limit == 0 {
+		limit = DefaultRowLimit
+	}
+
+	dbName, schema := SplitSchemaPath(database)
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return nil, 0, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+
+	tableName := _this.formatTableName(schema, table)
+	query := "SELECT * FROM " + tableName
+
+	if where != "" {
+		query += fmt.Sprintf(" %s", where)
+	}
+
+	if sort != "" {
+		query += fmt.Sprintf(" ORDER BY %s", sort)
+	}
+
+	query += " LIMIT $1 OFFSET $2"
+
+	slog.Debug("Executing query", "query", query, "offset", offset, "limit", limit)
+
+	paginatedRows, err := sqlDB.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	paginatedResults, _, err = scanRows(paginatedRows)
+	_ = paginatedRows.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := "SELECT COUNT(*) FROM " + tableName
+	if where != "" {
+		countQuery += fmt.Sprintf(" %s", where)
+	}
+	row := sqlDB.QueryRow(countQuery)
+	if err := row.Scan(&totalRecords); err != nil {
+		return nil, 0, err
+	}
+
+	return paginatedResults, totalRecords, nil
+}
+
+// GetPrimaryKeys 获取表的主键列，database 为 SchemaPath 组合的路径
+func (_this *PostgreSQLDriver) GetPrimaryKeys(database, table string) ([]string, error) {
+	dbName, schema := SplitSchemaPath(database)
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	keys, err := queryStrings(sqlDB, `SELECT a.attname FROM pg_index i
+JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+WHERE i.indrelid = $1::regclass AND i.indisprimary
+ORDER BY array_position(i.indkey::int2[], a.attnum)`, _this.formatTableName(schema, table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query primary keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (_this *PostgreSQLDriver) BuildStatements(database, table string, changes []RowChange) ([]Statement, error) {
+	_, schema := SplitSchemaPath(database)
+	return buildStatements(postgresDialect, _this.formatTableName(schema, table), changes)
+}
+
+func (_this *PostgreSQLDriver) ExecuteStatements(database string, statements []Statement) error {
+	dbName, _ := SplitSchemaPath(database)
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	return execInTransaction(sqlDB, statements)
+}
+
+// postgresFKActions pg_constraint 中外键动作的代码
+var postgresFKActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// GetTableStructure 从系统表获取表结构，PostgreSQL 没有 SHOW CREATE TABLE，建表语句按系统表拼接
+func (_this *PostgreSQLDriver) GetTableStructure(database, table string) (*TableStructure, error) {
+	dbName, schema := SplitSchemaPath(database)
+	if schema == "" {
+		schema = defaultPostgresSchema
+	}
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	s := &TableStructure{}
+	tableName := _this.formatTableName(schema, table)
+	args := []any{tableName}
+
+	err = queryEach(sqlDB, `SELECT a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+  pg_get_expr(d.adbin, d.adrelid), COALESCE(col_description(a.attrelid, a.attnum), '')
+FROM pg_attribute a
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, args,
+		func(rows *sql.Rows) error {
+			var c TableColumn
+			var def sql.NullString
+			if err := rows.Scan(&c.Name, &c.Type, &c.Nullable, &def, &c.Comment); err != nil {
+				return err
+			}
+			c.Default = nullString(def)
+			s.Columns = append(s.Columns, c)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+
+	// 表达式索引的列号为 0，使用索引定义中的表达式
+	err = queryEach(sqlDB, `SELECT i.relname, ix.indisunique, ix.indisprimary, am.amname,
+  COALESCE(a.attname, pg_get_indexdef(ix.indexrelid, k.ord::int, true))
+FROM pg_index ix
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_am am ON am.oid = i.relam
+CROSS JOIN LATERAL unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+LEFT JOIN pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum
+WHERE ix.indrelid = $1::regclass
+ORDER BY i.relname, k.ord`, args,
+		func(rows *sql.Rows) error {
+			var index TableIndex
+			var column string
+			if err := rows.Scan(&index.Name, &index.Unique, &index.Primary, &index.Type, &column); err != nil {
+				return err
+			}
+			index.Type = strings.ToUpper(index.Type)
+			s.Indexes = appendIndexColumn(s.Indexes, index, column)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexes: %w", err)
+	}
+	markColumnKeys(s)
+
+	err = queryEach(sqlDB, `SELECT c.conname, a.attname, c.confrelid::regclass::text, af.attname,
+  c.confupdtype::text, c.confdeltype::text
+FROM pg_constraint c
+CROSS JOIN LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refnum, ord)
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+JOIN pg_attribute af ON af.attrelid = c.confrelid AND af.attnum = k.refnum
+WHERE c.conrelid = $1::regclass AND c.contype = 'f'
+ORDER BY c.conname, k.ord`, args,
+		func(rows *sql.Rows) error {
+			var fk TableForeignKey
+			var column, refColumn string
+			if err := rows.Scan(&fk.Name, &column, &fk.RefTable, &refColumn, &fk.OnUpdate, &fk.OnDelete); err != nil {
+				return err
+			}
+			fk.OnUpdate, fk.OnDelete = postgresFKActions[fk.OnUpdate], postgresFKActions[fk.OnDelete]
+			s.ForeignKeys = appendForeignKeyColumn(s.ForeignKeys, fk, column, refColumn)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
+	}
+
+	err = queryEach(sqlDB, `SELECT trigger_name, action_timing, event_manipulation, action_statement
+FROM information_schema.triggers
+WHERE event_object_schema = $1 AND event_object_table = $2
+ORDER BY trigger_name, event_manipulation`, []any{schema, table},
+		func(rows *sql.Rows) error {
+			var t TableTrigger
+			if err := rows.Scan(&t.Name, &t.Timing, &t.Event, &t.Statement); err != nil {
+				return err
+			}
+			s.Triggers = appendTriggerEvent(s.Triggers, t)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query triggers: %w", err)
+	}
+
+	if s.DDL, err = _this._tableDDL(sqlDB, tableName, s); err != nil {
+		return nil, fmt.Errorf("failed to build ddl: %w", err)
+	}
+	return s, nil
+}
+
+// _tableDDL 按列定义、约束、索引、触发器和注释拼接建表语句，视图使用视图定义
+func (_this *PostgreSQLDriver) _tableDDL(sqlDB *sql.DB, tableName string, s *TableStructure) (string, error) {
+	var relkind string
+	if err := sqlDB.QueryRow("SELECT relkind::text FROM pg_class WHERE oid = $1::regclass", tableName).Scan(&relkind); err != nil {
+		return "", err
+	}
+	if relkind == "v" || relkind == "m" {
+		var def string
+		if err := sqlDB.QueryRow("SELECT pg_get_viewdef($1::regclass, true)", tableName).Scan(&def); err != nil {
+			return "", err
+		}
+		kind := "VIEW"
+		if relkind == "m" {
+			kind = "MATERIALIZED VIEW"
+		}
+		return fmt.Sprintf("CREATE %s %s AS\n%s", kind, tableName, def), nil
+	}
+
+	lines := make([]string, 0, len(s.Columns))
+	for _, c := range s.Columns {
+		line := "  " + quoteIdent(c.Name) + " " + c.Type
+		if !c.Nullable {
+			line += " NOT NULL"
+		}
+		if c.Default != nil {
+			line += " DEFAULT " + *c.Default
+		}
+		lines = append(lines, line)
+	}
+	err := queryEach(sqlDB, `SELECT conname, pg_get_constraintdef(oid, true) FROM pg_constraint
+WHERE conrelid = $1::regclass
+ORDER BY CASE contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'f' THEN 2 ELSE 3 END, conname`, []any{tableName},
+		func(rows *sql.Rows) error {
+			var name, def string
+			if err := rows.Scan(&name, &def); err != nil {
+				return err
+			}
+			lines = append(lines, "  CONSTRAINT "+quoteIdent(name)+" "+def)
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE TABLE " + tableName + " (\n" + strings.Join(lines, ",\n") + "\n);\n")
+	// 约束对应的索引已包含在约束中
+	err = queryEach(sqlDB, `SELECT pg_get_indexdef(i.indexrelid) FROM pg_index i
+WHERE i.indrelid = $1::regclass AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid)
+UNION ALL
+SELECT pg_get_triggerdef(t.oid, true) FROM pg_trigger t WHERE t.tgrelid = $1::regclass AND NOT t.tgisinternal`,
+		[]any{tableName},
+		func(rows *sql.Rows) error {
+			var def string
+			if err := rows.Scan(&def); err != nil {
+				return err
+			}
+			b.WriteString("\n" + def + ";")
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+	for _, c := range s.Columns {
+		if c.Comment != "" {
+			_, _ = fmt.Fprintf(&b, "\nCOMMENT ON COLUMN %s.%s IS '%s';",
+				tableName, quoteIdent(c.Name), strings.ReplaceAll(c.Comment, "'", "''"))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// ExecuteQuery 在指定的库中执行查询，database 为 SchemaPath 组合的路径，指定 schema 时设置 search_path
+func (_this *PostgreSQLDriver) ExecuteQuery(database, query string) ([][]string, int, error) {
+	var (
+		records [][]string
+		total   int
+	)
+	err := _this._queryIn(database, query, func(rows *sql.Rows) error {
+		var err error
+		records, total, err = scanRows(rows)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
+
+// _queryIn 在 database 对应的库中执行查询，指定 schema 时优先查找该 schema 下的表
+func (_this *PostgreSQLDriver) _queryIn(database, query string, fn func(rows *sql.Rows) error) error {
+	dbName, schema := SplitSchemaPath(database)
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	use := ""
+	if schema != "" {
+		use = "SET search_path TO " + quoteIdent(schema)
+	}
+	return queryIn(sqlDB, use, query, fn)
+}
+
+// StreamRecords 逐行读取整张表，database 为 SchemaPath 组合的路径
+func (_this *PostgreSQLDriver) StreamRecords(database, table, where, sort string, w RowWriter) error {
+	if table == "" {
+		return errors.New("table name is required")
+	}
+	if database == "" {
+		return errors.New("database name is required")
+	}
+
+	dbName, schema := SplitSchemaPath(database)
+	db, err := _this.getDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+
+	query := selectAll(_this.formatTableName(schema, table), where, sort)
+	slog.Debug("Streaming query", "query", query)
+	return streamQuery(sqlDB, query, w)
+}
+
+// StreamQuery 逐行读取查询结果，用于导出
+func (_this *PostgreSQLDriver) StreamQuery(database, query string, w RowWriter) error {
+	return _this._queryIn(database, query, func(rows *sql.Rows) error {
+		return streamRows(rows, w)
+	})
+}
+
+// CloseConnect 关闭默认连接和按库打开的连接
+func (_this *PostgreSQLDriver) CloseConnect() error {
+	var errs []error
+	_this.dbConns.Range(func(key, value any) bool {
+		_this.dbConns.Delete(key)
+		sqlDB, err := value.(*gorm.DB).DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to close connection to %s: %w", key, err))
+		}
+		return true
+	})
+	if err := _this.DatabaseConn.CloseConnect(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,369 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/19 14:36
+ */
+
+package database_drivers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+const defaultSQLiteDB = "main"
+
+type SQLiteDriver struct {
+	*DatabaseConn
+}
+
+func (_this *SQLiteDriver) formatTableName(database, table string) string {
+	if database == "" {
+		database = defaultSQLiteDB
+	}
+	return fmt.Sprintf("%s.%s", quoteIdent(database), quoteIdent(table))
+}
+
+func (_this *SQLiteDriver) GetDBConn() (*DatabaseConn, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return _this.DatabaseConn, nil
+}
+
+// GetDbList 获取当前文件中的数据库列表（main 以及 ATTACH 的库）
+func (_this *SQLiteDriver) GetDbList() ([]string, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	rows, err := sqlDB.Query("SELECT name FROM pragma_database_list ORDER BY seq")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query databases: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var databases []string
+	for rows.Next() {
+		var dbName string
+		if err := rows.Scan(&dbName); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		databases = append(databases, dbName)
+	}
+	return databases, rows.Err()
+}
+
+// GetTableList 获取指定库中的表和视图
+func (_this *SQLiteDriver) GetTableList(dbName string) ([]string, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dbName == "" {
+		dbName = defaultSQLiteDB
+	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	rows, err := sqlDB.Query(fmt.Sprintf(
+		"SELECT name FROM %s.sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite\\_%%' ESCAPE '\\' ORDER BY name",
+		quoteIdent(dbName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var tableList []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tableList = append(tableList, tableName)
+	}
+	return tableList, rows.Err()
+}
+
+func (_this *SQLiteDriver) GetRecords(
+	database, table, where, sort string,
+	offset, limit int,
+) (paginatedResults [][]string, totalRecords int, err error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if table == "" {
+		return nil, 0, errors.New("table name is required")
+	}
+
+	if limit == 0 {
+		limit = DefaultRowLimit
+	}
+
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+
+	tableName := _this.formatTableName(database, table)
+	query := "SELECT * FROM " + tableName
+
+	if where != "" {
+		query += fmt.Sprintf(" %s", where)
+	}
+
+	if sort != "" {
+		query += fmt.Sprintf(" ORDER BY %s", sort)
+	}
+
+	query += " LIMIT ? OFFSET ?"
+
+	slog.Debug("Executing query", "query", query, "offset", offset, "limit", limit)
+
+	paginatedRows, err := sqlDB.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	paginatedResults, _, err = scanRows(paginatedRows)
+	_ = paginatedRows.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := "SELECT COUNT(*) FROM " + tableName
+	if where != "" {
+		countQuery += fmt.Sprintf(" %s", where)
+	}
+	row := sqlDB.QueryRow(countQuery)
+	if err := row.Scan(&totalRecords); err != nil {
+		return nil, 0, err
+	}
+
+	return paginatedResults, totalRecords, nil
+}
+
+// GetPrimaryKeys 获取表的主键列，未声明主键的表返回空
+func (_this *SQLiteDriver) GetPrimaryKeys(database, table string) ([]string, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if database == "" {
+		database = defaultSQLiteDB
+	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	keys, err := queryStrings(sqlDB, "SELECT name FROM pragma_table_info(?, ?) WHERE pk > 0 ORDER BY pk", table, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query primary keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (_this *SQLiteDriver) BuildStatements(database, table string, changes []RowChange) ([]Statement, error) {
+	return buildStatements(sqliteDialect, _this.formatTableName(database, table), changes)
+}
+
+func (_this *SQLiteDriver) ExecuteStatements(database string, statements []Statement) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return err
+		}
+	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	return execInTransaction(sqlDB, statements)
+}
+
+// GetTableStructure 通过 PRAGMA 获取表结构，建表语句为 sqlite_master 中保存的原始语句
+func (_this *SQLiteDriver) GetTableStructure(database, table string) (*TableStructure, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if database == "" {
+		database = defaultSQLiteDB
+	}
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	s := &TableStructure{}
+	args := []any{table, database}
+
+	err = queryEach(sqlDB, `SELECT name, type, "notnull", dflt_value FROM pragma_table_xinfo(?, ?) ORDER BY cid`, args,
+		func(rows *sql.Rows) error {
+			var c TableColumn
+			var notNull bool
+			var def sql.NullString
+			if err := rows.Scan(&c.Name, &c.Type, &notNull, &def); err != nil {
+				return err
+			}
+			c.Nullable = !notNull
+			c.Default = nullString(def)
+			s.Columns = append(s.Columns, c)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+
+	// 索引的列需要按索引名逐个查询
+	err = queryEach(sqlDB, `SELECT l.name, l."unique", l.origin = 'pk', COALESCE(i.name, '(expression)')
+FROM pragma_index_list(?, ?) l
+JOIN pragma_index_info(l.name, ?) i
+ORDER BY l.name, i.seqno`, []any{table, database, database},
+		func(rows *sql.Rows) error {
+			var index TableIndex
+			var column string
+			if err := rows.Scan(&index.Name, &index.Unique, &index.Primary, &column); err != nil {
+				return err
+			}
+			s.Indexes = appendIndexColumn(s.Indexes, index, column)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexes: %w", err)
+	}
+	// INTEGER PRIMARY KEY 等主键不一定有对应的索引，按 table_info 补充
+	if !slices.ContainsFunc(s.Indexes, func(index TableIndex) bool { return index.Primary }) {
+		if keys, err := _this.GetPrimaryKeys(database, table); err == nil && len(keys) > 0 {
+			s.Indexes = append([]TableIndex{{Name: "PRIMARY", Columns: keys, Unique: true, Primary: true}}, s.Indexes...)
+		}
+	}
+	markColumnKeys(s)
+
+	// SQLite 的外键没有名称，按 id 区分
+	err = queryEach(sqlDB, `SELECT id, "table", "from", COALESCE("to", ''), on_update, on_delete
+FROM pragma_foreign_key_list(?, ?) ORDER BY id, seq`, args,
+		func(rows *sql.Rows) error {
+			var fk TableForeignKey
+			var id int
+			var column, refColumn string
+			if err := rows.Scan(&id, &fk.RefTable, &column, &refColumn, &fk.OnUpdate, &fk.OnDelete); err != nil {
+				return err
+			}
+			fk.Name = fmt.Sprintf("#%d", id)
+			s.ForeignKeys = appendForeignKeyColumn(s.ForeignKeys, fk, column, refColumn)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
+	}
+
+	var ddl []string
+	err = queryEach(sqlDB, fmt.Sprintf(
+		"SELECT type, name, sql FROM %s.sqlite_master WHERE tbl_name = ? AND sql IS NOT NULL ORDER BY type <> 'table' AND type <> 'view', type, name",
+		quoteIdent(database),
+	), []any{table},
+		func(rows *sql.Rows) error {
+			var kind, name, stmt string
+			if err := rows.Scan(&kind, &name, &stmt); err != nil {
+				return err
+			}
+			// 触发器的时机和事件包含在语句中，不再单独解析
+			if kind == "trigger" {
+				s.Triggers = append(s.Triggers, TableTrigger{Name: name, Statement: stmt})
+			}
+			ddl = append(ddl, stmt+";")
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ddl: %w", err)
+	}
+	s.DDL = strings.Join(ddl, "\n\n")
+	return s, nil
+}
+
+// ExecuteQuery SQLite 只有一个库，忽略 database
+func (_this *SQLiteDriver) ExecuteQuery(database, query string) ([][]string, int, error) {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+
+	rows, err := sqlDB.Query(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return scanRows(rows)
+}
+
+// StreamRecords 逐行读取整张表，用于导出
+func (_this *SQLiteDriver) StreamRecords(database, table, where, sort string, w RowWriter) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return err
+		}
+	}
+	if table == "" {
+		return errors.New("table name is required")
+	}
+
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+
+	query := selectAll(_this.formatTableName(database, table), where, sort)
+	slog.Debug("Streaming query", "query", query)
+	return streamQuery(sqlDB, query, w)
+}
+
+// StreamQuery 逐行读取查询结果，用于导出
+func (_this *SQLiteDriver) StreamQuery(database, query string, w RowWriter) error {
+	if _this.dbConn == nil {
+		err := _this.InitConnect()
+		if err != nil {
+			return err
+		}
+	}
+
+	sqlDB, err := _this.dbConn.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
+	}
+	return streamQuery(sqlDB, query, w)
+}
@@ -0,0 +1,38 @@
+package database_drivers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+)
+
+// newSQLiteTestDriver 在临时文件上创建 SQLite 驱动，并依次执行建表等语句
+func newSQLiteTestDriver(t *testing.T, ddl ...string) *SQLiteDriver {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	driver := &SQLiteDriver{
+		DatabaseConn: &DatabaseConn{
+			cfg: &config.DBConnection{
+				Provider: config.DatabaseProviderSQLite,
+				FilePath: path,
+			},
+		},
+	}
+	if err := driver.InitConnect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = driver.CloseConnect()
+	})
+	for _, stmt := range ddl {
+		if _, _, err := driver.ExecuteQuery("", stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return driver
+}
@@ -0,0 +1,149 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/29 10:00
+ */
+
+package database_drivers
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// TableColumn 表的列定义
+type TableColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+	Default  *string // 为 nil 时没有默认值
+	Key      string  // PRI、UNI、MUL，与 MySQL 的 COLUMN_KEY 一致
+	Extra    string  // 如 auto_increment
+	Comment  string
+}
+
+// TableIndex 表的索引
+type TableIndex struct {
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+	Type    string // 如 BTREE、HASH
+}
+
+// TableForeignKey 表的外键
+type TableForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnUpdate   string
+	OnDelete   string
+}
+
+// TableTrigger 表上的触发器
+type TableTrigger struct {
+	Name      string
+	Timing    string // BEFORE、AFTER、INSTEAD OF
+	Event     string // INSERT、UPDATE、DELETE，多个事件用 OR 连接
+	Statement string
+}
+
+// TableStructure 表结构
+type TableStructure struct {
+	Columns     []TableColumn
+	Indexes     []TableIndex
+	ForeignKeys []TableForeignKey
+	Triggers    []TableTrigger
+	DDL         string // 建表语句
+}
+
+// appendIndexColumn 按索引名追加列，查询结果需按索引名排序
+func appendIndexColumn(indexes []TableIndex, index TableIndex, column string) []TableIndex {
+	if n := len(indexes); n > 0 && indexes[n-1].Name == index.Name {
+		indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+		return indexes
+	}
+	index.Columns = []string{column}
+	return append(indexes, index)
+}
+
+// appendForeignKeyColumn 按外键名追加列，查询结果需按外键名排序
+func appendForeignKeyColumn(fks []TableForeignKey, fk TableForeignKey, column, refColumn string) []TableForeignKey {
+	if n := len(fks); n > 0 && fks[n-1].Name == fk.Name {
+		fks[n-1].Columns = append(fks[n-1].Columns, column)
+		fks[n-1].RefColumns = append(fks[n-1].RefColumns, refColumn)
+		return fks
+	}
+	fk.Columns = []string{column}
+	fk.RefColumns = []string{refColumn}
+	return append(fks, fk)
+}
+
+// appendTriggerEvent 同一触发器的多个事件合并为一条，查询结果需按触发器名排序
+func appendTriggerEvent(triggers []TableTrigger, trigger TableTrigger) []TableTrigger {
+	if n := len(triggers); n > 0 && triggers[n-1].Name == trigger.Name {
+		triggers[n-1].Event += " OR " + trigger.Event
+		return triggers
+	}
+	return append(triggers, trigger)
+}
+
+// markColumnKeys 按索引标记列的 Key，规则与 MySQL 的 COLUMN_KEY 相同
+// 主键的每一列都为 PRI，UNI 和 MUL 只看索引的第一列
+func markColumnKeys(s *TableStructure) {
+	keys := make(map[string]string)
+	mark := func(column, key string) {
+		// PRI 优先于 UNI，UNI 优先于 MUL
+		if current, ok := keys[column]; !ok || keyPriority(key) > keyPriority(current) {
+			keys[column] = key
+		}
+	}
+	for _, index := range s.Indexes {
+		if len(index.Columns) == 0 {
+			continue
+		}
+		if index.Primary {
+			for _, column := range index.Columns {
+				mark(column, "PRI")
+			}
+			continue
+		}
+		key := "MUL"
+		if index.Unique && len(index.Columns) == 1 {
+			key = "UNI"
+		}
+		mark(index.Columns[0], key)
+	}
+	for i := range s.Columns {
+		s.Columns[i].Key = keys[s.Columns[i].Name]
+	}
+}
+
+func keyPriority(key string) int {
+	return strings.Index("MUL UNI PRI", key)
+}
+
+// queryEach 执行查询并对每一行调用 fn，结束后关闭结果集
+func queryEach(sqlDB *sql.DB, query string, args []any, fn func(rows *sql.Rows) error) error {
+	rows, err := sqlDB.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		if err = fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// nullString 将可能为 NULL 的列转换为指针
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
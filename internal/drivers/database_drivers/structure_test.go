@@ -0,0 +1,100 @@
+package database_drivers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkColumnKeys(t *testing.T) {
+	s := &TableStructure{
+		Columns: []TableColumn{{Name: "id"}, {Name: "email"}, {Name: "tenant"}, {Name: "name"}},
+		Indexes: []TableIndex{
+			{Name: "idx_tenant_name", Columns: []string{"tenant", "name"}, Unique: true},
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "uk_email", Columns: []string{"email"}, Unique: true},
+			{Name: "idx_id", Columns: []string{"id"}},
+		},
+	}
+	markColumnKeys(s)
+	var keys []string
+	for _, c := range s.Columns {
+		keys = append(keys, c.Key)
+	}
+	if want := []string{"PRI", "UNI", "MUL", ""}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("got %v, want %v", keys, want)
+	}
+
+	// 联合主键的每一列都是 PRI，第二列上的普通索引不影响
+	s = &TableStructure{
+		Columns: []TableColumn{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Indexes: []TableIndex{
+			{Name: "idx_b", Columns: []string{"b"}},
+			{Name: "PRIMARY", Columns: []string{"a", "b"}, Unique: true, Primary: true},
+			{Name: "idx_c_a", Columns: []string{"c", "a"}},
+		},
+	}
+	markColumnKeys(s)
+	keys = keys[:0]
+	for _, c := range s.Columns {
+		keys = append(keys, c.Key)
+	}
+	if want := []string{"PRI", "PRI", "MUL"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("composite key: got %v, want %v", keys, want)
+	}
+}
+
+func TestSQLiteTableStructure(t *testing.T) {
+	driver := newSQLiteTestDriver(t,
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE)",
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id) ON DELETE CASCADE, total REAL DEFAULT 0)",
+		"CREATE INDEX idx_orders_user ON orders (user_id, total)",
+		"CREATE TRIGGER trg_orders AFTER INSERT ON orders BEGIN SELECT 1; END",
+	)
+
+	s, err := driver.GetTableStructure("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := "0"
+	wantColumns := []TableColumn{
+		{Name: "id", Type: "INTEGER", Nullable: true, Key: "PRI"},
+		{Name: "user_id", Type: "INTEGER", Nullable: true, Key: "MUL"},
+		{Name: "total", Type: "REAL", Nullable: true, Default: &def},
+	}
+	if !reflect.DeepEqual(s.Columns, wantColumns) {
+		t.Fatalf("unexpected columns %+v", s.Columns)
+	}
+	wantIndexes := []TableIndex{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+		{Name: "idx_orders_user", Columns: []string{"user_id", "total"}},
+	}
+	if !reflect.DeepEqual(s.Indexes, wantIndexes) {
+		t.Fatalf("unexpected indexes %+v", s.Indexes)
+	}
+	wantFKs := []TableForeignKey{{
+		Name:       "#0",
+		Columns:    []string{"user_id"},
+		RefTable:   "users",
+		RefColumns: []string{"id"},
+		OnUpdate:   "NO ACTION",
+		OnDelete:   "CASCADE",
+	}}
+	if !reflect.DeepEqual(s.ForeignKeys, wantFKs) {
+		t.Fatalf("unexpected foreign keys %+v", s.ForeignKeys)
+	}
+	if len(s.Triggers) != 1 || s.Triggers[0].Name != "trg_orders" {
+		t.Fatalf("unexpected triggers %+v", s.Triggers)
+	}
+	if !strings.HasPrefix(s.DDL, "CREATE TABLE orders") || !strings.Contains(s.DDL, "CREATE INDEX idx_orders_user") {
+		t.Fatalf("unexpected ddl %s", s.DDL)
+	}
+
+	s, err = driver.GetTableStructure("", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Columns[1].Key != "UNI" || s.Columns[1].Nullable {
+		t.Fatalf("unexpected email column %+v", s.Columns[1])
+	}
+}
@@ -0,0 +1,202 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/24 10:15
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/api/types/network"
+)
+
+// docker compose 写入容器的标签
+const (
+	ComposeProjectLabel = "com.docker.compose.project"
+	ComposeServiceLabel = "com.docker.compose.service"
+)
+
+// LogLineFn 日志行回调，source 为日志来源的服务名称
+type LogLineFn func(source, line string)
+
+// ComposeProject 同一 compose 项目下的容器
+type ComposeProject struct {
+	Name       string
+	Containers []*container.Summary
+}
+
+// Running 项目中运行中的容器数量
+func (_this *ComposeProject) Running() int {
+	count := 0
+	for _, ctr := range _this.Containers {
+		if ctr.State == "running" {
+			count++
+		}
+	}
+	return count
+}
+
+// ComposeService 容器所属的 compose 服务名称，非 compose 容器返回容器名称
+func ComposeService(ctr *container.Summary) string {
+	if service := ctr.Labels[ComposeServiceLabel]; service != "" {
+		return service
+	}
+	return containerName(ctr)
+}
+
+// GroupComposeProjects 按 compose 项目对容器分组，返回按名称排序的项目及不属于任何项目的容器
+func GroupComposeProjects(list []*container.Summary) ([]*ComposeProject, []*container.Summary) {
+	byName := make(map[string]*ComposeProject)
+	var projects []*ComposeProject
+	var standalone []*container.Summary
+	for _, ctr := range list {
+		name := ctr.Labels[ComposeProjectLabel]
+		if name == "" {
+			standalone = append(standalone, ctr)
+			continue
+		}
+		project, ok := byName[name]
+		if !ok {
+			project = &ComposeProject{Name: name}
+			byName[name] = project
+			projects = append(projects, project)
+		}
+		project.Containers = append(project.Containers, ctr)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	for _, project := range projects {
+		sort.SliceStable(project.Containers, func(i, j int) bool {
+			return containerName(project.Containers[i]) < containerName(project.Containers[j])
+		})
+	}
+	return projects, standalone
+}
+
+// ListComposeContainers 获取 compose 项目下的所有容器
+func ListComposeContainers(project string) ([]*container.Summary, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", ComposeProjectLabel+"="+project)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers of project %s: %w", project, err)
+	}
+	list := make([]*container.Summary, 0, len(containers))
+	for i := range containers {
+		list = append(list, &containers[i])
+	}
+	return list, nil
+}
+
+// RestartComposeProject 重启项目下的所有容器
+func RestartComposeProject(project string, timeout *int) error {
+	containers, err := ListComposeContainers(project)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ctr := range containers {
+		errs = append(errs, RestartContainer(ctr.ID, timeout))
+	}
+	return errors.Join(errs...)
+}
+
+// StopComposeProject 停止项目下所有运行中的容器，并等待其退出
+func StopComposeProject(project string, timeout *int) error {
+	containers, err := ListComposeContainers(project)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ctr := range containers {
+		if ctr.State != "running" {
+			continue
+		}
+		if err := StopContainer(ctr.ID, timeout); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, WaitContainerStopped(ctr.ID, 60*time.Second))
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveComposeProject 删除项目下的所有容器以及项目创建的网络
+func RemoveComposeProject(project string, force bool) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	containers, err := ListComposeContainers(project)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ctr := range containers {
+		errs = append(errs, RemoveContainer(ctr.ID, force))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+	networks, err := cli.NetworkList(context.Background(), network.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", ComposeProjectLabel+"="+project)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list networks of project %s: %w", project, err)
+	}
+	for _, n := range networks {
+		errs = append(errs, RemoveNetwork(n.ID))
+	}
+	return errors.Join(errs...)
+}
+
+// ComposeProjectLogs 合并读取项目下所有容器的日志，直至 ctx 取消或所有容器日志结束
+func ComposeProjectLogs(ctx context.Context, project string, fn LogLineFn) error {
+	containers, err := ListComposeContainers(project)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no containers found for project %s", project)
+	}
+	// 同一服务有多个副本时使用容器名称区分来源
+	services := make(map[string]int, len(containers))
+	for _, ctr := range containers {
+		services[ComposeService(ctr)]++
+	}
+	var wg sync.WaitGroup
+	for _, ctr := range containers {
+		source := ComposeService(ctr)
+		if services[source] > 1 {
+			source = containerName(ctr)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := LogOptions{Tail: 100, Follow: true}
+			err := StreamContainerLogs(ctx, ctr.ID, opts, func(line *LogLine) {
+				fn(source, line.Text)
+			})
+			if err != nil {
+				slog.Warn("Container logs stream stopped", "containerID", ctr.ID, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,41 @@
+package docker_drivers
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+func TestGroupComposeProjects(t *testing.T) {
+	compose := func(name, project, service, state string) *container.Summary {
+		return &container.Summary{
+			ID:     name + "-0123456789",
+			Names:  []string{"/" + name},
+			State:  state,
+			Labels: map[string]string{ComposeProjectLabel: project, ComposeServiceLabel: service},
+		}
+	}
+	list := []*container.Summary{
+		compose("shop-web-1", "shop", "web", "running"),
+		{ID: "standalone-0123456789", Names: []string{"/standalone"}},
+		compose("blog-db-1", "blog", "db", "exited"),
+		compose("shop-db-1", "shop", "db", "running"),
+	}
+	projects, standalone := GroupComposeProjects(list)
+	if len(projects) != 2 || projects[0].Name != "blog" || projects[1].Name != "shop" {
+		t.Fatalf("unexpected projects: %+v", projects)
+	}
+	shop := projects[1]
+	if len(shop.Containers) != 2 || containerName(shop.Containers[0]) != "shop-db-1" {
+		t.Errorf("shop containers not sorted by name: %v", shop.Containers)
+	}
+	if shop.Running() != 2 || projects[0].Running() != 0 {
+		t.Errorf("running = %d/%d, want 2/0", shop.Running(), projects[0].Running())
+	}
+	if len(standalone) != 1 || ComposeService(standalone[0]) != "standalone" {
+		t.Errorf("unexpected standalone containers: %v", standalone)
+	}
+	if got := ComposeService(shop.Containers[1]); got != "web" {
+		t.Errorf("ComposeService = %q, want web", got)
+	}
+}
@@ -0,0 +1,335 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/25 10:20
+ */
+
+package docker_drivers
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// CopyProgressFn 复制进度回调，total 未知时为 0
+type CopyProgressFn func(copied, total int64)
+
+// copyProgressInterval 进度回调的最小间隔
+const copyProgressInterval = 100 * time.Millisecond
+
+// CopyToContainer 将本机的文件或目录上传到容器，目标为已存在的目录时复制到该目录下，否则按目标路径重命名
+func CopyToContainer(containerID, srcPath, dstPath string, preserve bool, progress CopyProgressFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if _, err = os.Lstat(srcPath); err != nil {
+		return fmt.Errorf("failed to stat %s: %w", srcPath, err)
+	}
+	ctx := context.Background()
+	dstDir, name := dstPath, filepath.Base(srcPath)
+	if stat, err := cli.ContainerStatPath(ctx, containerID, dstPath); err != nil || !stat.Mode.IsDir() {
+		dstDir, name = path.Dir(dstPath), path.Base(dstPath)
+	}
+	total, err := localSize(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		counter := newCopyCounter(total, progress)
+		err := writeTar(pw, srcPath, name, preserve, counter)
+		counter.done()
+		_ = pw.CloseWithError(err)
+	}()
+	defer func() {
+		_ = pr.Close()
+	}()
+	err = cli.CopyToContainer(ctx, containerID, dstDir, pr, container.CopyToContainerOptions{
+		CopyUIDGID: preserve,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to container %s:%s: %w", srcPath, containerID, dstPath, err)
+	}
+	return nil
+}
+
+// CopyFromContainer 将容器中的文件或目录下载到本机，目标为已存在的目录时复制到该目录下，否则按目标路径重命名
+func CopyFromContainer(containerID, srcPath, dstPath string, preserve bool, progress CopyProgressFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	reader, stat, err := cli.CopyFromContainer(context.Background(), containerID, srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s from container %s: %w", srcPath, containerID, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	dstDir, rename := dstPath, ""
+	if fi, err := os.Stat(dstPath); err != nil || !fi.IsDir() {
+		dstDir, rename = filepath.Dir(dstPath), filepath.Base(dstPath)
+	}
+	// 目录的大小无法提前获取，只展示已复制的字节数
+	var total int64
+	if stat.Mode.IsRegular() {
+		total = stat.Size
+	}
+	counter := newCopyCounter(total, progress)
+	defer counter.done()
+	if err = extractTar(reader, dstDir, rename, preserve, counter); err != nil {
+		return fmt.Errorf("failed to copy %s from container %s to %s: %w", srcPath, containerID, dstPath, err)
+	}
+	return nil
+}
+
+// localSize 本机文件或目录下所有普通文件的总大小
+func localSize(root string) (int64, error) {
+	var total int64
+	err := filepath.Walk(root, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			total += fi.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// defaultFileMode 不保留权限时使用的默认权限，保留可执行位
+func defaultFileMode(mode os.FileMode) os.FileMode {
+	if mode.IsDir() || mode&0o111 != 0 {
+		return 0o755
+	}
+	return 0o644
+}
+
+// writeTar 将 srcPath 打包为 tar，根目录在包中命名为 name
+func writeTar(w io.Writer, srcPath, name string, preserve bool, counter *copyCounter) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(srcPath, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcPath, p)
+		if err != nil {
+			return err
+		}
+		link := ""
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if !preserve {
+			hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+			hdr.Mode = int64(defaultFileMode(fi.Mode()))
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		_, err = io.Copy(tw, counter.reader(f))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractTar 将 tar 解压到 dstDir，rename 不为空时替换包中的根目录名称
+func extractTar(r io.Reader, dstDir, rename string, preserve bool, counter *copyCounter) error {
+	root, err := filepath.Abs(dstDir)
+	if err != nil {
+		return err
+	}
+	type dirMeta struct {
+		path  string
+		mode  os.FileMode
+		mtime time.Time
+	}
+	var dirs []dirMeta
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if rename != "" {
+			_, rest, _ := strings.Cut(name, "/")
+			name = path.Join(rename, rest)
+		}
+		target := filepath.Join(root, filepath.FromSlash(name))
+		if err = ensureWithin(root, filepath.Dir(target)); err != nil {
+			return err
+		}
+		mode := defaultFileMode(hdr.FileInfo().Mode())
+		if preserve {
+			mode = hdr.FileInfo().Mode().Perm()
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirMeta{path: target, mode: mode, mtime: hdr.ModTime})
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			// 已存在的同名符号链接先删除，避免写入链接指向的文件
+			if fi, err := os.Lstat(target); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+				_ = os.Remove(target)
+			}
+			if err = writeFile(target, tr, mode, counter); err != nil {
+				return err
+			}
+			if preserve {
+				if err = os.Chmod(target, mode); err != nil {
+					return err
+				}
+				_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+			}
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			_ = os.Remove(target)
+			if err = os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+	// 目录的权限和时间在写完其中的文件后再设置，避免只读目录无法写入
+	if !preserve {
+		return nil
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i].path, dirs[i].mode); err != nil {
+			return err
+		}
+		_ = os.Chtimes(dirs[i].path, dirs[i].mtime, dirs[i].mtime)
+	}
+	return nil
+}
+
+func writeFile(target string, r io.Reader, mode os.FileMode, counter *copyCounter) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, counter.reader(r)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ensureWithin 确认目录解析符号链接后仍在 root 下，防止 tar 中的路径写到目标目录之外
+func ensureWithin(root, dir string) error {
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	// 目录可能尚未创建，向上找到已存在的部分再解析
+	existing := dir
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		existing = parent
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("invalid path %s outside of %s", dir, root)
+	}
+	return nil
+}
+
+// copyCounter 统计已复制的字节数并按间隔回调进度
+type copyCounter struct {
+	copied   int64
+	total    int64
+	last     time.Time
+	progress CopyProgressFn
+}
+
+func newCopyCounter(total int64, progress CopyProgressFn) *copyCounter {
+	return &copyCounter{total: total, progress: progress}
+}
+
+func (_this *copyCounter) reader(r io.Reader) io.Reader {
+	return &countingReader{Reader: r, counter: _this}
+}
+
+func (_this *copyCounter) add(n int) {
+	_this.copied += int64(n)
+	if _this.progress != nil && time.Since(_this.last) >= copyProgressInterval {
+		_this.last = time.Now()
+		_this.progress(_this.copied, _this.total)
+	}
+}
+
+// done 复制结束时回调最终进度
+func (_this *copyCounter) done() {
+	if _this.progress != nil {
+		_this.progress(_this.copied, _this.total)
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	counter *copyCounter
+}
+
+func (_this *countingReader) Read(p []byte) (int, error) {
+	n, err := _this.Reader.Read(p)
+	_this.counter.add(n)
+	return n, err
+}
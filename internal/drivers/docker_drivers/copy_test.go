@@ -0,0 +1,82 @@
+package docker_drivers
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "conf", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "conf", "app.yaml"), []byte("port: 80\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "conf", "sub", "run.sh"), []byte("#!/bin/sh\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	var copied int64
+	for _, preserve := range []bool{true, false} {
+		var buf bytes.Buffer
+		counter := newCopyCounter(0, func(n, _ int64) { copied = n })
+		if err := writeTar(&buf, filepath.Join(src, "conf"), "conf", preserve, counter); err != nil {
+			t.Fatalf("writeTar: %v", err)
+		}
+		counter.done()
+
+		dst := t.TempDir()
+		if err := extractTar(&buf, dst, "renamed", preserve, newCopyCounter(0, nil)); err != nil {
+			t.Fatalf("extractTar: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dst, "renamed", "app.yaml"))
+		if err != nil || string(data) != "port: 80\n" {
+			t.Fatalf("app.yaml = %q, %v", data, err)
+		}
+		if runtime.GOOS == "windows" {
+			continue
+		}
+		fi, err := os.Stat(filepath.Join(dst, "renamed", "app.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := os.FileMode(0o600)
+		if !preserve {
+			want = 0o644
+		}
+		if fi.Mode().Perm()&^0o022 != want&^0o022 {
+			t.Errorf("preserve=%v: app.yaml mode = %v, want %v", preserve, fi.Mode().Perm(), want)
+		}
+		if fi, err = os.Stat(filepath.Join(dst, "renamed", "sub", "run.sh")); err != nil || fi.Mode()&0o100 == 0 {
+			t.Errorf("preserve=%v: run.sh lost its executable bit: %v, %v", preserve, fi, err)
+		}
+	}
+	if copied != int64(len("port: 80\n")+len("#!/bin/sh\n")) {
+		t.Errorf("copied = %d", copied)
+	}
+}
+
+func TestExtractTarRejectsSymlinkEscape(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need extra privileges on windows")
+	}
+	outside := t.TempDir()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "data/link", Typeflag: tar.TypeSymlink, Linkname: outside})
+	_ = tw.WriteHeader(&tar.Header{Name: "data/link/evil", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4})
+	_, _ = tw.Write([]byte("evil"))
+	_ = tw.Close()
+
+	if err := extractTar(&buf, t.TempDir(), "", false, newCopyCounter(0, nil)); err == nil {
+		t.Fatal("extractTar wrote through a symlink outside of the target directory")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "evil")); !os.IsNotExist(err) {
+		t.Errorf("file written outside of the target directory: %v", err)
+	}
+}
@@ -3,33 +3,47 @@ package docker_drivers
 import (
 	"context"
 	"fmt"
-	"io"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/liangzhaoliang95/lxz/internal/helper"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
 	"github.com/moby/moby/api/types/image"
 	"github.com/moby/moby/client"
 )
 
-var dockerClient *client.Client
+// DockerKillSignalList 结束容器时可选的信号
+var DockerKillSignalList = []string{"SIGKILL", "SIGTERM", "SIGINT", "SIGHUP", "SIGQUIT", "SIGUSR1", "SIGUSR2"}
+
+var (
+	clientMu     sync.RWMutex
+	dockerClient *client.Client
+	currentHost  = defaultDockerHost // 当前连接的引擎
+)
 
 func InitDockerClient() error {
-	var err error
-	dockerClient, err = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, err := newDockerClient(defaultDockerHost)
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
+	clientMu.Lock()
+	dockerClient, currentHost = cli, defaultDockerHost
+	clientMu.Unlock()
 	return nil
 }
 
 func GetDockerClient() (*client.Client, error) {
-	if dockerClient == nil {
+	clientMu.RLock()
+	cli := dockerClient
+	clientMu.RUnlock()
+	if cli == nil {
 		if err := InitDockerClient(); err != nil {
 			return nil, err
 		}
+		return GetDockerClient()
 	}
-	return dockerClient, nil
+	return cli, nil
 }
 
 func ListContainers() ([]*container.Summary, error) {
@@ -43,12 +57,30 @@ func ListContainers() ([]*container.Summary, error) {
 	}
 	list := make([]*container.Summary, 0, len(containers))
 	for _, item := range containers {
-		fmt.Println(helper.Prettify(item))
 		list = append(list, &item)
 	}
 	return list, nil
 }
 
+// GetContainer 按ID获取容器的摘要信息，容器不存在时返回 nil
+func GetContainer(containerID string) (*container.Summary, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("id", containerID)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %s: %w", containerID, err)
+	}
+	if len(containers) == 0 {
+		return nil, nil
+	}
+	return &containers[0], nil
+}
+
 func RestartContainer(containerID string, timeout *int) error {
 	cli, err := GetDockerClient()
 	if err != nil {
@@ -62,23 +94,6 @@ func RestartContainer(containerID string, timeout *int) error {
 	return nil
 }
 
-func ContainerLogs(containerID string) (io.ReadCloser, error) {
-	cli, err := GetDockerClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get Docker client: %w", err)
-	}
-	reader, err := cli.ContainerLogs(context.TODO(), containerID, container.LogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Follow:     true,
-		Tail:       "100",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get logs for container %s: %w", containerID, err)
-	}
-	return reader, nil
-}
-
 func RemoveContainer(containerID string, force bool) error {
 	cli, err := GetDockerClient()
 	if err != nil {
@@ -106,6 +121,66 @@ func StopContainer(containerID string, timeout *int) error {
 	return nil
 }
 
+// StartContainer starts a stopped Docker container by its ID.
+func StartContainer(containerID string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.ContainerStart(context.Background(), containerID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// PauseContainer suspends all processes in a running Docker container.
+func PauseContainer(containerID string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.ContainerPause(context.Background(), containerID); err != nil {
+		return fmt.Errorf("failed to pause container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// UnpauseContainer resumes all processes in a paused Docker container.
+func UnpauseContainer(containerID string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.ContainerUnpause(context.Background(), containerID); err != nil {
+		return fmt.Errorf("failed to unpause container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// KillContainer sends a signal such as SIGKILL or SIGTERM to a running Docker container.
+func KillContainer(containerID, signal string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.ContainerKill(context.Background(), containerID, signal); err != nil {
+		return fmt.Errorf("failed to kill container %s with %s: %w", containerID, signal, err)
+	}
+	return nil
+}
+
+// RenameContainer changes the name of a Docker container.
+func RenameContainer(containerID, newName string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.ContainerRename(context.Background(), containerID, newName); err != nil {
+		return fmt.Errorf("failed to rename container %s to %s: %w", containerID, newName, err)
+	}
+	return nil
+}
+
 func InspectContainer(containerID string) (*container.InspectResponse, error) {
 	cli, err := GetDockerClient()
 	if err != nil {
@@ -118,6 +193,50 @@ func InspectContainer(containerID string) (*container.InspectResponse, error) {
 	return &containerInfo, nil
 }
 
+// ContainerTop lists the processes running inside a Docker container, like docker top.
+func ContainerTop(containerID string) (*container.TopResponse, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	top, err := cli.ContainerTop(context.Background(), containerID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes of container %s: %w", containerID, err)
+	}
+	return &top, nil
+}
+
+// ContainerDiff lists the changed files in a Docker container's filesystem sorted by path, like docker diff.
+func ContainerDiff(containerID string) ([]container.FilesystemChange, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	changes, err := cli.ContainerDiff(context.Background(), containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff container %s: %w", containerID, err)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// CountFilesystemChanges counts the modified, added and deleted paths.
+func CountFilesystemChanges(changes []container.FilesystemChange) (modified, added, deleted int) {
+	for _, c := range changes {
+		switch c.Kind {
+		case container.ChangeModify:
+			modified++
+		case container.ChangeAdd:
+			added++
+		case container.ChangeDelete:
+			deleted++
+		}
+	}
+	return modified, added, deleted
+}
+
 // InspectImage inspects a Docker image by its ID or name.
 func InspectImage(imageID string) (*image.InspectResponse, error) {
 	cli, err := GetDockerClient()
@@ -0,0 +1,200 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/26 10:10
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/events"
+)
+
+// eventsRetryInterval 事件流断开后重新订阅的间隔
+const eventsRetryInterval = 3 * time.Second
+
+// EventFn 事件回调
+type EventFn func(msg events.Message)
+
+// WatchEvents 订阅引擎的事件流直到 ctx 结束，断开后从最后一个事件的时间重新订阅，since 为零值时只接收新事件
+func WatchEvents(ctx context.Context, since time.Time, fn EventFn) {
+	for ctx.Err() == nil {
+		err := streamEvents(ctx, since, func(msg events.Message) {
+			since = EventTime(msg)
+			fn(msg)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("Docker events stream closed, retrying", "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventsRetryInterval):
+		}
+	}
+}
+
+func streamEvents(ctx context.Context, since time.Time, fn EventFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	var opts events.ListOptions
+	if !since.IsZero() {
+		// 加 1 纳秒避免重复接收最后一个事件
+		opts.Since = formatEventTime(since.Add(time.Nanosecond))
+	}
+	messages, errs := cli.Events(ctx, opts)
+	for {
+		select {
+		case msg := <-messages:
+			fn(msg)
+		case err := <-errs:
+			if err == nil {
+				return errors.New("events stream closed")
+			}
+			return err
+		}
+	}
+}
+
+// formatEventTime 转换为事件接口接受的 秒.纳秒 格式
+func formatEventTime(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// EventTime 事件发生的时间
+func EventTime(msg events.Message) time.Time {
+	if msg.TimeNano > 0 {
+		return time.Unix(0, msg.TimeNano)
+	}
+	return time.Unix(msg.Time, 0)
+}
+
+// EventActorName 事件对象的名称，没有名称时返回短ID
+func EventActorName(msg events.Message) string {
+	if name := msg.Actor.Attributes["name"]; name != "" {
+		return name
+	}
+	if len(msg.Actor.ID) > 12 && !strings.Contains(msg.Actor.ID, ":") {
+		return msg.Actor.ID[:12]
+	}
+	return msg.Actor.ID
+}
+
+// IsContainerLifecycleEvent 会改变容器列表内容的容器事件
+func IsContainerLifecycleEvent(msg events.Message) bool {
+	if msg.Type != events.ContainerEventType {
+		return false
+	}
+	switch msg.Action {
+	case events.ActionCreate, events.ActionStart, events.ActionRestart, events.ActionStop,
+		events.ActionDie, events.ActionKill, events.ActionPause, events.ActionUnPause,
+		events.ActionRename, events.ActionDestroy, events.ActionUpdate, events.ActionOOM:
+		return true
+	}
+	// health_status: healthy 等健康状态变化
+	return strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus))
+}
+
+// EventFilter 按事件类型和动作过滤，为空时不过滤
+type EventFilter struct {
+	Type   events.Type
+	Action string // 动作前缀，exec_start 等动作后面会带上命令
+}
+
+// Match 事件是否满足过滤条件
+func (_this EventFilter) Match(msg events.Message) bool {
+	if _this.Type != "" && msg.Type != _this.Type {
+		return false
+	}
+	return _this.Action == "" || strings.HasPrefix(string(msg.Action), _this.Action)
+}
+
+// String 过滤条件的展示文本
+func (_this EventFilter) String() string {
+	typ, action := string(_this.Type), _this.Action
+	if typ == "" {
+		typ = "all"
+	}
+	if action == "" {
+		action = "all"
+	}
+	return "type:" + typ + " action:" + action
+}
+
+// EventHistory 最近的事件记录，超出容量时丢弃最早的事件
+type EventHistory struct {
+	mx       sync.RWMutex
+	capacity int
+	messages []events.Message
+}
+
+func NewEventHistory(capacity int) *EventHistory {
+	return &EventHistory{capacity: max(capacity, 1)}
+}
+
+// Add 记录一个事件
+func (_this *EventHistory) Add(msg events.Message) {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	_this.messages = append(_this.messages, msg)
+	if len(_this.messages) > _this.capacity {
+		_this.messages = _this.messages[len(_this.messages)-_this.capacity:]
+	}
+}
+
+// List 按时间顺序返回满足过滤条件的事件
+func (_this *EventHistory) List(filter EventFilter) []events.Message {
+	_this.mx.RLock()
+	defer _this.mx.RUnlock()
+	list := make([]events.Message, 0, len(_this.messages))
+	for _, msg := range _this.messages {
+		if filter.Match(msg) {
+			list = append(list, msg)
+		}
+	}
+	return list
+}
+
+// Last 最后一个事件的时间，没有事件时为零值
+func (_this *EventHistory) Last() time.Time {
+	_this.mx.RLock()
+	defer _this.mx.RUnlock()
+	if len(_this.messages) == 0 {
+		return time.Time{}
+	}
+	return EventTime(_this.messages[len(_this.messages)-1])
+}
+
+// Clear 清空事件记录，切换引擎时使用
+func (_this *EventHistory) Clear() {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	_this.messages = nil
+}
+
+// FormatEventAttributes 以 key=value 的形式展示事件属性，name 已单独展示
+func FormatEventAttributes(msg events.Message) string {
+	keys := make([]string, 0, len(msg.Actor.Attributes))
+	for k := range msg.Actor.Attributes {
+		if k != "name" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+msg.Actor.Attributes[k])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,49 @@
+package docker_drivers
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/events"
+)
+
+func TestEventHistory(t *testing.T) {
+	history := NewEventHistory(3)
+	history.Add(events.Message{Type: events.ImageEventType, Action: events.ActionPull, TimeNano: 1})
+	history.Add(events.Message{Type: events.ContainerEventType, Action: events.ActionStart, TimeNano: 2})
+	history.Add(events.Message{Type: events.ContainerEventType, Action: "exec_start: sh", TimeNano: 3})
+	history.Add(events.Message{Type: events.ContainerEventType, Action: events.ActionDie, TimeNano: 4})
+
+	if list := history.List(EventFilter{}); len(list) != 3 || list[0].TimeNano != 2 {
+		t.Fatalf("expected the oldest event to be dropped, got %+v", list)
+	}
+	if list := history.List(EventFilter{Type: events.ImageEventType}); len(list) != 0 {
+		t.Errorf("expected no image events, got %+v", list)
+	}
+	if list := history.List(EventFilter{Action: "exec_"}); len(list) != 1 || list[0].TimeNano != 3 {
+		t.Errorf("expected action prefix match, got %+v", list)
+	}
+	if last := history.Last(); last.UnixNano() != 4 {
+		t.Errorf("unexpected last event time %v", last)
+	}
+	history.Clear()
+	if !history.Last().IsZero() {
+		t.Errorf("expected empty history after clear")
+	}
+}
+
+func TestIsContainerLifecycleEvent(t *testing.T) {
+	tests := []struct {
+		msg  events.Message
+		want bool
+	}{
+		{events.Message{Type: events.ContainerEventType, Action: events.ActionStart}, true},
+		{events.Message{Type: events.ContainerEventType, Action: events.ActionHealthStatusHealthy}, true},
+		{events.Message{Type: events.ContainerEventType, Action: "exec_start: sh"}, false},
+		{events.Message{Type: events.ImageEventType, Action: events.ActionDelete}, false},
+	}
+	for _, tt := range tests {
+		if got := IsContainerLifecycleEvent(tt.msg); got != tt.want {
+			t.Errorf("IsContainerLifecycleEvent(%s %s) = %v, want %v", tt.msg.Type, tt.msg.Action, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 10:20
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+)
+
+// ExecSession 容器内的一个交互式 exec 会话
+type ExecSession struct {
+	ID     string
+	cli    *client.Client
+	stream client.HijackedResponse
+}
+
+// ExecAttach 在容器内创建带 TTY 的 exec 并附加到其标准输入输出
+func ExecAttach(containerID string, cmd []string, height, width uint) (*ExecSession, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	var consoleSize *[2]uint
+	if height > 0 && width > 0 {
+		consoleSize = &[2]uint{height, width}
+	}
+	resp, err := cli.ContainerExecCreate(context.Background(), containerID, container.ExecOptions{
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		ConsoleSize:  consoleSize,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exec in container %s: %w", containerID, err)
+	}
+	stream, err := cli.ContainerExecAttach(context.Background(), resp.ID, container.ExecAttachOptions{
+		Tty:         true,
+		ConsoleSize: consoleSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach exec in container %s: %w", containerID, err)
+	}
+	return &ExecSession{ID: resp.ID, cli: cli, stream: stream}, nil
+}
+
+// Stream 交互读写流，TTY 模式下输出未做多路复用，可直接拷贝
+func (_this *ExecSession) Stream() (io.Reader, io.Writer) {
+	return _this.stream.Reader, _this.stream.Conn
+}
+
+// CloseWrite 关闭写入端，通知容器内进程输入结束
+func (_this *ExecSession) CloseWrite() error {
+	return _this.stream.CloseWrite()
+}
+
+// Resize 调整 exec 的 TTY 尺寸
+func (_this *ExecSession) Resize(height, width uint) error {
+	if height == 0 || width == 0 {
+		return nil
+	}
+	err := _this.cli.ContainerExecResize(context.Background(), _this.ID, container.ResizeOptions{
+		Height: height,
+		Width:  width,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resize exec %s: %w", _this.ID, err)
+	}
+	return nil
+}
+
+// ExitCode 获取 exec 进程的退出码
+func (_this *ExecSession) ExitCode() (int, error) {
+	inspect, err := _this.cli.ContainerExecInspect(context.Background(), _this.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect exec %s: %w", _this.ID, err)
+	}
+	return inspect.ExitCode, nil
+}
+
+func (_this *ExecSession) Close() {
+	_this.stream.Close()
+}
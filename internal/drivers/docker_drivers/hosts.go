@@ -0,0 +1,227 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/25 15:40
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/ssh_drivers"
+	"github.com/moby/moby/client"
+)
+
+// Docker 引擎的来源
+const (
+	DockerHostSourceEnv     = "env"     // DOCKER_HOST 等环境变量
+	DockerHostSourceContext = "context" // docker context
+	DockerHostSourceConfig  = "config"  // lxz 配置文件
+)
+
+const (
+	defaultDockerSocket = "/var/run/docker.sock"
+	pingTimeout         = 10 * time.Second
+)
+
+// DockerHost 可切换的 Docker 引擎
+type DockerHost struct {
+	Name   string
+	Host   string // 为空时按环境变量连接
+	Source string
+	TLS    config.TLSConfig
+	Socket string // ssh:// 地址时远端 Docker 的 socket 路径
+}
+
+// Address 引擎地址的展示文本
+func (_this *DockerHost) Address() string {
+	if _this.Host != "" {
+		return _this.Host
+	}
+	if host := os.Getenv(client.EnvOverrideHost); host != "" {
+		return host
+	}
+	return "local"
+}
+
+var defaultDockerHost = &DockerHost{Name: "default", Source: DockerHostSourceEnv}
+
+// ListDockerHosts 获取可切换的引擎：环境变量、docker context 以及配置文件中的引擎
+func ListDockerHosts(hosts []*config.DockerHostConfig) []*DockerHost {
+	list := []*DockerHost{defaultDockerHost}
+	contexts, err := LoadDockerContexts(dockerConfigDir())
+	if err != nil {
+		slog.Warn("Failed to load docker contexts", "error", err)
+	}
+	list = append(list, contexts...)
+	for _, h := range hosts {
+		list = append(list, &DockerHost{
+			Name:   h.Name,
+			Host:   h.Host,
+			Source: DockerHostSourceConfig,
+			TLS:    h.TLS,
+			Socket: h.Socket,
+		})
+	}
+	return list
+}
+
+// dockerConfigDir Docker CLI 的配置目录，优先使用 DOCKER_CONFIG
+func dockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// dockerContextMeta contexts/meta/<hash>/meta.json 的内容
+type dockerContextMeta struct {
+	Name      string `json:"Name"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// LoadDockerContexts 读取 Docker CLI 的 context，TLS 证书位于 contexts/tls/<hash>/docker 下
+func LoadDockerContexts(dir string) ([]*DockerHost, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	metaDir := filepath.Join(dir, "contexts", "meta")
+	entries, err := os.ReadDir(metaDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", metaDir, err)
+	}
+	var hosts []*DockerHost
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		bb, err := os.ReadFile(filepath.Join(metaDir, entry.Name(), "meta.json"))
+		if err != nil {
+			continue
+		}
+		var meta dockerContextMeta
+		if err = json.Unmarshal(bb, &meta); err != nil {
+			slog.Warn("Invalid docker context meta", "dir", entry.Name(), "error", err)
+			continue
+		}
+		endpoint, ok := meta.Endpoints["docker"]
+		if !ok || endpoint.Host == "" || meta.Name == defaultDockerHost.Name {
+			continue
+		}
+		host := &DockerHost{Name: meta.Name, Host: endpoint.Host, Source: DockerHostSourceContext}
+		tlsDir := filepath.Join(dir, "contexts", "tls", entry.Name(), "docker")
+		if _, err = os.Stat(tlsDir); err == nil {
+			host.TLS = config.TLSConfig{Enabled: true, SkipVerify: endpoint.SkipTLSVerify}
+			for file, field := range map[string]*string{
+				"ca.pem":   &host.TLS.CAFile,
+				"cert.pem": &host.TLS.CertFile,
+				"key.pem":  &host.TLS.KeyFile,
+			} {
+				if _, err = os.Stat(filepath.Join(tlsDir, file)); err == nil {
+					*field = filepath.Join(tlsDir, file)
+				}
+			}
+		}
+		hosts = append(hosts, host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Name < hosts[j].Name
+	})
+	return hosts, nil
+}
+
+// newDockerClient 按引擎地址创建客户端，ssh:// 地址经由 SSH 隧道连接远端的 Docker socket
+func newDockerClient(host *DockerHost) (*client.Client, error) {
+	if host.Host == "" {
+		return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	}
+	u, err := url.Parse(host.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %s: %w", host.Host, err)
+	}
+	if u.Scheme == "ssh" {
+		target := u.Host
+		if u.User != nil {
+			target = u.User.Username() + "@" + u.Host
+		}
+		socket := host.Socket
+		if socket == "" {
+			socket = defaultDockerSocket
+		}
+		return client.NewClientWithOpts(
+			// 实际连接由 DialContext 建立，地址只用于生成请求 URL
+			client.WithHost("http://docker.example.com"),
+			client.WithDialContext(func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return ssh_drivers.Dial(ctx, target, "unix", socket)
+			}),
+			client.WithAPIVersionNegotiation(),
+		)
+	}
+
+	var opts []client.Opt
+	tlsConfig, err := host.TLS.Build(u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, client.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}))
+	}
+	opts = append(opts, client.WithHost(host.Host), client.WithAPIVersionNegotiation())
+	return client.NewClientWithOpts(opts...)
+}
+
+// SwitchDockerHost 连接指定的引擎，连接成功后替换当前客户端
+func SwitchDockerHost(host *DockerHost) error {
+	cli, err := newDockerClient(host)
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client for %s: %w", host.Name, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if _, err = cli.Ping(ctx); err != nil {
+		_ = cli.Close()
+		return fmt.Errorf("failed to connect docker host %s: %w", host.Name, err)
+	}
+
+	clientMu.Lock()
+	old := dockerClient
+	dockerClient, currentHost = cli, host
+	clientMu.Unlock()
+	if old != nil {
+		_ = old.Close()
+	}
+	slog.Info("Switched docker host", "name", host.Name, "host", host.Address())
+	return nil
+}
+
+// CurrentDockerHost 当前连接的引擎
+func CurrentDockerHost() *DockerHost {
+	clientMu.RLock()
+	defer clientMu.RUnlock()
+	return currentHost
+}
@@ -0,0 +1,54 @@
+package docker_drivers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDockerContexts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("contexts/meta/b1/meta.json",
+		`{"Name":"remote","Endpoints":{"docker":{"Host":"tcp://10.0.0.1:2376","SkipTLSVerify":true}}}`)
+	writeFile("contexts/tls/b1/docker/ca.pem", "ca")
+	writeFile("contexts/tls/b1/docker/cert.pem", "cert")
+	writeFile("contexts/meta/a1/meta.json",
+		`{"Name":"build","Endpoints":{"docker":{"Host":"ssh://root@build"}}}`)
+	writeFile("contexts/meta/c1/meta.json", `{"Name":"default","Endpoints":{"docker":{"Host":"unix:///var/run/docker.sock"}}}`)
+	writeFile("contexts/meta/d1/meta.json", `not json`)
+
+	hosts, err := LoadDockerContexts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 contexts, got %d", len(hosts))
+	}
+	if hosts[0].Name != "build" || hosts[0].Host != "ssh://root@build" || hosts[0].TLS.Enabled {
+		t.Errorf("unexpected context %+v", hosts[0])
+	}
+	remote := hosts[1]
+	if remote.Name != "remote" || remote.Source != DockerHostSourceContext {
+		t.Errorf("unexpected context %+v", remote)
+	}
+	if !remote.TLS.Enabled || !remote.TLS.SkipVerify {
+		t.Errorf("expected tls enabled with skip verify, got %+v", remote.TLS)
+	}
+	if remote.TLS.CAFile == "" || remote.TLS.CertFile == "" || remote.TLS.KeyFile != "" {
+		t.Errorf("unexpected tls files %+v", remote.TLS)
+	}
+
+	hosts, err = LoadDockerContexts(filepath.Join(dir, "missing"))
+	if err != nil || len(hosts) != 0 {
+		t.Errorf("expected no contexts for missing dir, got %v, %v", hosts, err)
+	}
+}
@@ -0,0 +1,195 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 14:10
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client/pkg/jsonmessage"
+)
+
+// PullProgressFn 拉取镜像的进度回调，status 为可直接展示的单行进度
+type PullProgressFn func(status string)
+
+// ImageData 镜像列表中的一行，同一镜像有多个标签时按标签拆分
+type ImageData struct {
+	ID         string
+	Repository string
+	Tag        string
+	Size       int64
+	Created    int64
+	Containers []string // 使用该镜像的容器名称
+}
+
+// Reference 镜像引用，未打标签的镜像使用 ID
+func (_this *ImageData) Reference() string {
+	if _this.Repository == "<none>" {
+		return _this.ID
+	}
+	return _this.Repository + ":" + _this.Tag
+}
+
+// ListImages 获取镜像列表，并统计每个镜像被哪些容器使用
+func ListImages() ([]*ImageData, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	images, err := cli.ImageList(context.Background(), image.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	usedBy := make(map[string][]string)
+	for i := range containers {
+		usedBy[containers[i].ImageID] = append(usedBy[containers[i].ImageID], containerName(&containers[i]))
+	}
+
+	list := make([]*ImageData, 0, len(images))
+	for _, item := range images {
+		tags := item.RepoTags
+		if len(tags) == 0 {
+			tags = []string{"<none>:<none>"}
+		}
+		for _, repoTag := range tags {
+			repo, tag := splitRepoTag(repoTag)
+			list = append(list, &ImageData{
+				ID:         item.ID,
+				Repository: repo,
+				Tag:        tag,
+				Size:       item.Size,
+				Created:    item.Created,
+				Containers: usedBy[item.ID],
+			})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Created > list[j].Created
+	})
+	return list, nil
+}
+
+// splitRepoTag 拆分 repo:tag，需考虑带端口的仓库地址
+func splitRepoTag(repoTag string) (string, string) {
+	i := strings.LastIndex(repoTag, ":")
+	if i < 0 || strings.Contains(repoTag[i+1:], "/") {
+		return repoTag, "latest"
+	}
+	return repoTag[:i], repoTag[i+1:]
+}
+
+// PullImage 拉取镜像，逐条回调拉取进度直至完成
+func PullImage(ref string, progress PullProgressFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	reader, err := cli.ImagePull(context.Background(), ref, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", ref, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	// 记录每一层的最新状态，用于统计已完成的层数
+	layers := make(map[string]string)
+	decoder := json.NewDecoder(reader)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read pull progress of %s: %w", ref, err)
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("failed to pull image %s: %s", ref, msg.Error.Message)
+		}
+		if msg.ID != "" && !strings.HasPrefix(msg.Status, "Pulling from") {
+			layers[msg.ID] = msg.Status
+		}
+		if progress != nil {
+			progress(formatPullProgress(&msg, layers))
+		}
+	}
+}
+
+func formatPullProgress(msg *jsonmessage.JSONMessage, layers map[string]string) string {
+	done := 0
+	for _, status := range layers {
+		if status == "Pull complete" || status == "Already exists" {
+			done++
+		}
+	}
+	line := msg.Status
+	if msg.ID != "" {
+		line = msg.ID + ": " + line
+	}
+	if msg.Progress != nil && msg.Progress.Total > 0 {
+		line += fmt.Sprintf(" %s/%s",
+			units.HumanSize(float64(msg.Progress.Current)),
+			units.HumanSize(float64(msg.Progress.Total)))
+	}
+	if len(layers) > 0 {
+		line += fmt.Sprintf("\n%d/%d layers done", done, len(layers))
+	}
+	return line
+}
+
+// RemoveImage 删除镜像，force 为 true 时即使有容器引用也强制删除
+func RemoveImage(ref string, force bool) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if _, err = cli.ImageRemove(context.Background(), ref, image.RemoveOptions{
+		Force:         force,
+		PruneChildren: true,
+	}); err != nil {
+		return fmt.Errorf("failed to remove image %s: %w", ref, err)
+	}
+	return nil
+}
+
+// ImageHistory 获取镜像的构建层历史
+func ImageHistory(ref string) ([]image.HistoryResponseItem, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	history, err := cli.ImageHistory(context.Background(), ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of image %s: %w", ref, err)
+	}
+	return history, nil
+}
+
+// PruneDanglingImages 清理未打标签且未被使用的镜像，返回删除数量和回收空间
+func PruneDanglingImages() (int, uint64, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	report, err := cli.ImagesPrune(context.Background(), filters.NewArgs(filters.Arg("dangling", "true")))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune dangling images: %w", err)
+	}
+	return len(report.ImagesDeleted), report.SpaceReclaimed, nil
+}
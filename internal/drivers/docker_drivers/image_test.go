@@ -0,0 +1,20 @@
+package docker_drivers
+
+import "testing"
+
+func TestSplitRepoTag(t *testing.T) {
+	cases := map[string][2]string{
+		"nginx:1.27":                   {"nginx", "1.27"},
+		"nginx":                        {"nginx", "latest"},
+		"registry.local:5000/app:v1":   {"registry.local:5000/app", "v1"},
+		"registry.local:5000/app":      {"registry.local:5000/app", "latest"},
+		"<none>:<none>":                {"<none>", "<none>"},
+		"ghcr.io/org/tool:sha-1a2b3c4": {"ghcr.io/org/tool", "sha-1a2b3c4"},
+	}
+	for repoTag, want := range cases {
+		repo, tag := splitRepoTag(repoTag)
+		if repo != want[0] || tag != want[1] {
+			t.Errorf("splitRepoTag(%q) = %q, %q, want %q, %q", repoTag, repo, tag, want[0], want[1])
+		}
+	}
+}
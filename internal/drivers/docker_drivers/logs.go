@@ -0,0 +1,172 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/24 15:10
+ */
+
+package docker_drivers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+)
+
+// LogOptions 读取容器日志的参数
+type LogOptions struct {
+	Tail   int64         // 最近的行数，<=0 读取全部
+	Since  time.Duration // 只读取最近一段时间的日志，<=0 不限制
+	Follow bool          // 持续跟随新日志
+}
+
+// maxLogLineSize 单行日志保留的最大字节数，超出的部分丢弃
+const maxLogLineSize = 1024 * 1024
+
+// LogLine 一行容器日志
+type LogLine struct {
+	Stderr    bool
+	Time      time.Time
+	Text      string
+	Truncated bool // 行过长，超出 maxLogLineSize 的部分已丢弃
+}
+
+// LogFn 日志行回调，stdout 和 stderr 在不同的 goroutine 中回调
+type LogFn func(line *LogLine)
+
+// StreamContainerLogs 按行读取容器日志，直至 ctx 取消或日志结束；非 TTY 容器的日志会拆分 stdout/stderr
+func StreamContainerLogs(ctx context.Context, containerID string, opts LogOptions, fn LogFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	info, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+	logsOpts := container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Timestamps: true,
+		Tail:       "all",
+	}
+	if opts.Tail > 0 {
+		logsOpts.Tail = strconv.FormatInt(opts.Tail, 10)
+	}
+	if opts.Since > 0 {
+		logsOpts.Since = strconv.FormatInt(time.Now().Add(-opts.Since).Unix(), 10)
+	}
+	reader, err := cli.ContainerLogs(ctx, containerID, logsOpts)
+	if err != nil {
+		return fmt.Errorf("failed to get logs for container %s: %w", containerID, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	if info.Config != nil && info.Config.Tty {
+		return scanLogLines(ctx, containerID, reader, false, fn)
+	}
+
+	return scanMultiplexedLogs(ctx, containerID, reader, fn)
+}
+
+// scanMultiplexedLogs 非 TTY 容器的日志是 stdout/stderr 复用流，拆分后分别按行读取
+func scanMultiplexedLogs(ctx context.Context, containerID string, reader io.Reader, fn LogFn) error {
+	outR, outW := io.Pipe()
+	errR, errW := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(outW, errW, reader)
+		_ = outW.CloseWithError(err)
+		_ = errW.CloseWithError(err)
+	}()
+
+	// 一路读取出错时关闭两路管道，StdCopy 写入失败后退出，另一路随之结束，只返回第一个错误
+	var (
+		wg       sync.WaitGroup
+		stopOnce sync.Once
+		firstErr error
+	)
+	for i, r := range []*io.PipeReader{outR, errR} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := scanLogLines(ctx, containerID, r, i == 1, fn); err != nil {
+				stopOnce.Do(func() {
+					firstErr = err
+					_ = outR.CloseWithError(err)
+					_ = errR.CloseWithError(err)
+				})
+				return
+			}
+			_ = r.Close()
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// scanLogLines 按行读取日志，过长的行截断后标记为 Truncated，不中断读取
+func scanLogLines(ctx context.Context, containerID string, r io.Reader, stderr bool, fn LogFn) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	var (
+		buf       []byte
+		truncated bool
+	)
+	appendLimited := func(p []byte) {
+		if n := maxLogLineSize - len(buf); len(p) > n {
+			p, truncated = p[:n], true
+		}
+		buf = append(buf, p...)
+	}
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			// 行还没有结束
+			appendLimited(chunk)
+			continue
+		}
+		appendLimited(bytes.TrimSuffix(chunk, []byte("\n")))
+		if err == nil || len(buf) > 0 || truncated {
+			raw := string(bytes.TrimSuffix(buf, []byte("\r")))
+			if truncated {
+				// 截断处可能在多字节字符中间
+				raw = strings.ToValidUTF8(raw, "")
+			}
+			line := parseLogLine(raw, stderr)
+			line.Truncated = truncated
+			fn(line)
+		}
+		buf, truncated = buf[:0], false
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read logs for container %s: %w", containerID, err)
+		}
+	}
+}
+
+// parseLogLine 解析带时间戳前缀的日志行，时间戳格式为 RFC3339Nano
+func parseLogLine(raw string, stderr bool) *LogLine {
+	line := &LogLine{Stderr: stderr, Text: raw}
+	ts, text, ok := strings.Cut(raw, " ")
+	if !ok {
+		ts = raw
+		text = ""
+	}
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		line.Time = t
+		line.Text = text
+	}
+	return line
+}
@@ -0,0 +1,83 @@
+package docker_drivers
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+)
+
+func TestParseLogLine(t *testing.T) {
+	line := parseLogLine("2025-08-24T07:10:11.123456789Z GET /health 200", true)
+	want := time.Date(2025, 8, 24, 7, 10, 11, 123456789, time.UTC)
+	if !line.Time.Equal(want) || line.Text != "GET /health 200" || !line.Stderr {
+		t.Errorf("parseLogLine = %+v", line)
+	}
+
+	// 空行只有时间戳
+	if line = parseLogLine("2025-08-24T07:10:11Z", false); line.Time.IsZero() || line.Text != "" {
+		t.Errorf("parseLogLine(timestamp only) = %+v", line)
+	}
+
+	// 没有时间戳时保留原文
+	if line = parseLogLine("plain text line", false); !line.Time.IsZero() || line.Text != "plain text line" {
+		t.Errorf("parseLogLine(no timestamp) = %+v", line)
+	}
+}
+
+func TestScanMultiplexedLogsLongLine(t *testing.T) {
+	// stdout 中有超过上限的行，截断后两路的日志仍继续读取
+	var buf bytes.Buffer
+	stdout := stdcopy.NewStdWriter(&buf, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(&buf, stdcopy.Stderr)
+	_, _ = stderr.Write([]byte("2025-08-24T07:10:11Z first\n"))
+	_, _ = stdout.Write([]byte("2025-08-24T07:10:11Z " + strings.Repeat("x", 2*maxLogLineSize) + "\n"))
+	for range 100 {
+		_, _ = stdout.Write([]byte("2025-08-24T07:10:12Z out\r\n"))
+		_, _ = stderr.Write([]byte("2025-08-24T07:10:12Z err\n"))
+	}
+
+	var (
+		mx    sync.Mutex
+		lines []*LogLine
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- scanMultiplexedLogs(context.Background(), "c1", &buf, func(line *LogLine) {
+			mx.Lock()
+			lines = append(lines, line)
+			mx.Unlock()
+		})
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scanMultiplexedLogs did not return")
+	}
+
+	if len(lines) != 202 {
+		t.Fatalf("got %d lines, want 202", len(lines))
+	}
+	var truncated int
+	for _, line := range lines {
+		switch {
+		case line.Truncated:
+			truncated++
+			if line.Time.IsZero() || len(line.Text) != maxLogLineSize-len("2025-08-24T07:10:11Z ") {
+				t.Errorf("unexpected truncated line: time %v, %d bytes", line.Time, len(line.Text))
+			}
+		case line.Text != "out" && line.Text != "err" && line.Text != "first":
+			t.Errorf("unexpected line %q", line.Text)
+		}
+	}
+	if truncated != 1 {
+		t.Fatalf("got %d truncated lines, want 1", truncated)
+	}
+}
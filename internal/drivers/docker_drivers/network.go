@@ -0,0 +1,192 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 10:10
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/api/types/network"
+)
+
+// DockerNetworkDriverList 可创建的网络驱动
+var DockerNetworkDriverList = []string{"bridge", "overlay", "macvlan", "ipvlan"}
+
+// NetworkData 网络列表中的一行
+type NetworkData struct {
+	ID         string
+	Name       string
+	Driver     string
+	Scope      string
+	Subnet     string
+	Internal   bool
+	Created    time.Time
+	Containers []string // 接入该网络的容器名称
+}
+
+// containerName 容器的展示名称，去掉名称前的 /
+func containerName(ctr *container.Summary) string {
+	if len(ctr.Names) > 0 {
+		return strings.TrimPrefix(ctr.Names[0], "/")
+	}
+	return ctr.ID[:12]
+}
+
+// ListContainerNames 获取所有容器名称
+func ListContainerNames() ([]string, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	names := make([]string, 0, len(containers))
+	for i := range containers {
+		names = append(names, containerName(&containers[i]))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListNetworks 获取网络列表，并统计每个网络接入的容器
+func ListNetworks() ([]*NetworkData, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	networks, err := cli.NetworkList(context.Background(), network.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	// 列表接口不返回接入的容器，从容器的网络配置中反查
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	attached := make(map[string][]string)
+	for i := range containers {
+		if containers[i].NetworkSettings == nil {
+			continue
+		}
+		for _, endpoint := range containers[i].NetworkSettings.Networks {
+			if endpoint == nil {
+				continue
+			}
+			attached[endpoint.NetworkID] = append(attached[endpoint.NetworkID], containerName(&containers[i]))
+		}
+	}
+
+	list := make([]*NetworkData, 0, len(networks))
+	for _, item := range networks {
+		subnets := make([]string, 0, len(item.IPAM.Config))
+		for _, cfg := range item.IPAM.Config {
+			if cfg.Subnet != "" {
+				subnets = append(subnets, cfg.Subnet)
+			}
+		}
+		list = append(list, &NetworkData{
+			ID:         item.ID,
+			Name:       item.Name,
+			Driver:     item.Driver,
+			Scope:      item.Scope,
+			Subnet:     strings.Join(subnets, ", "),
+			Internal:   item.Internal,
+			Created:    item.Created,
+			Containers: attached[item.ID],
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// InspectNetwork 获取网络详情
+func InspectNetwork(networkID string) (*network.Inspect, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	networkInfo, err := cli.NetworkInspect(context.Background(), networkID, network.InspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect network %s: %w", networkID, err)
+	}
+	return &networkInfo, nil
+}
+
+// CreateNetwork 创建网络
+func CreateNetwork(name, driver string, internal bool) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if _, err = cli.NetworkCreate(context.Background(), name, network.CreateOptions{
+		Driver:   driver,
+		Internal: internal,
+	}); err != nil {
+		return fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveNetwork 删除网络
+func RemoveNetwork(networkID string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.NetworkRemove(context.Background(), networkID); err != nil {
+		return fmt.Errorf("failed to remove network %s: %w", networkID, err)
+	}
+	return nil
+}
+
+// PruneNetworks 清理未被任何容器使用的自定义网络，返回被删除的网络名称
+func PruneNetworks() ([]string, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	report, err := cli.NetworksPrune(context.Background(), filters.NewArgs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune networks: %w", err)
+	}
+	return report.NetworksDeleted, nil
+}
+
+// ConnectNetwork 将容器接入网络
+func ConnectNetwork(networkID, containerID string) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.NetworkConnect(context.Background(), networkID, containerID, nil); err != nil {
+		return fmt.Errorf("failed to connect container %s to network %s: %w", containerID, networkID, err)
+	}
+	return nil
+}
+
+// DisconnectNetwork 将容器从网络断开
+func DisconnectNetwork(networkID, containerID string, force bool) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.NetworkDisconnect(context.Background(), networkID, containerID, force); err != nil {
+		return fmt.Errorf("failed to disconnect container %s from network %s: %w", containerID, networkID, err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/27 10:00
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+)
+
+// DockerRestartPolicyList 可选的重启策略
+var DockerRestartPolicyList = []string{
+	string(container.RestartPolicyDisabled),
+	string(container.RestartPolicyAlways),
+	string(container.RestartPolicyOnFailure),
+	string(container.RestartPolicyUnlessStopped),
+}
+
+// RunOptions 创建并启动容器的参数
+type RunOptions struct {
+	Image         string
+	Name          string   // 为空时由 Docker 生成
+	Command       []string // 为空时使用镜像的默认命令
+	Env           []string // KEY=VALUE
+	Ports         []string // [ip:][hostPort:]containerPort[/protocol]
+	Volumes       []string // 数据卷或本机路径:容器路径[:ro]
+	Network       string   // 为空时使用默认的 bridge 网络
+	RestartPolicy string
+	AutoRemove    bool
+}
+
+// RunContainer 按参数创建容器并启动，返回容器ID，启动失败时保留已创建的容器便于排查
+func RunContainer(opts *RunOptions) (string, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	exposed, bindings, err := ParsePortMappings(opts.Ports)
+	if err != nil {
+		return "", err
+	}
+	restart := container.RestartPolicy{Name: container.RestartPolicyMode(opts.RestartPolicy)}
+	if restart.IsNone() {
+		restart.Name = container.RestartPolicyDisabled
+	}
+	if opts.AutoRemove && !restart.IsNone() {
+		return "", fmt.Errorf("auto remove conflicts with restart policy %s", restart.Name)
+	}
+
+	cfg := &container.Config{
+		Image:        opts.Image,
+		Cmd:          opts.Command,
+		Env:          opts.Env,
+		ExposedPorts: exposed,
+	}
+	hostCfg := &container.HostConfig{
+		Binds:         opts.Volumes,
+		PortBindings:  bindings,
+		RestartPolicy: restart,
+		AutoRemove:    opts.AutoRemove,
+	}
+	var netCfg *network.NetworkingConfig
+	if opts.Network != "" {
+		hostCfg.NetworkMode = container.NetworkMode(opts.Network)
+		netCfg = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{opts.Network: {}},
+		}
+	}
+
+	ctx := context.Background()
+	resp, err := cli.ContainerCreate(ctx, cfg, hostCfg, netCfg, nil, opts.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create container from %s: %w", opts.Image, err)
+	}
+	if err = cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return resp.ID, fmt.Errorf("container %s created but failed to start: %w", resp.ID[:12], err)
+	}
+	return resp.ID, nil
+}
+
+// ParsePortMappings 解析端口映射，格式与 docker run -p 相同，只写容器端口时由 Docker 分配本机端口
+func ParsePortMappings(specs []string) (container.PortSet, container.PortMap, error) {
+	exposed := container.PortSet{}
+	bindings := container.PortMap{}
+	for _, spec := range specs {
+		rawSpec := spec
+		proto := "tcp"
+		if p, pr, ok := strings.Cut(spec, "/"); ok {
+			spec, proto = p, strings.ToLower(pr)
+		}
+		if proto != "tcp" && proto != "udp" && proto != "sctp" {
+			return nil, nil, fmt.Errorf("invalid protocol in port mapping %s", rawSpec)
+		}
+		var hostIP, hostPort, containerPort string
+		// IPv6 地址需写在方括号中，如 [::1]:8080:80
+		if strings.HasPrefix(spec, "[") {
+			end := strings.Index(spec, "]")
+			if end < 0 || end+1 >= len(spec) || spec[end+1] != ':' {
+				return nil, nil, fmt.Errorf("invalid port mapping %s", rawSpec)
+			}
+			hostIP, spec = spec[1:end], spec[end+2:]
+			parts := strings.Split(spec, ":")
+			if len(parts) != 2 {
+				return nil, nil, fmt.Errorf("invalid port mapping %s", rawSpec)
+			}
+			hostPort, containerPort = parts[0], parts[1]
+		} else {
+			parts := strings.Split(spec, ":")
+			switch len(parts) {
+			case 1:
+				containerPort = parts[0]
+			case 2:
+				hostPort, containerPort = parts[0], parts[1]
+			case 3:
+				hostIP, hostPort, containerPort = parts[0], parts[1], parts[2]
+			default:
+				return nil, nil, fmt.Errorf("invalid port mapping %s", rawSpec)
+			}
+		}
+		if hostIP != "" && net.ParseIP(hostIP) == nil {
+			return nil, nil, fmt.Errorf("invalid host ip in port mapping %s", rawSpec)
+		}
+		if !validPort(containerPort) || (hostPort != "" && !validPort(hostPort)) {
+			return nil, nil, fmt.Errorf("invalid port in port mapping %s", rawSpec)
+		}
+		port := container.PortRangeProto(containerPort + "/" + proto)
+		exposed[port] = struct{}{}
+		bindings[port] = append(bindings[port], container.PortBinding{HostIP: hostIP, HostPort: hostPort})
+	}
+	return exposed, bindings, nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// SplitCommand 按 shell 的规则拆分命令，支持单双引号和反斜杠转义
+func SplitCommand(cmd string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if escaped || quote != 0 {
+		return nil, fmt.Errorf("unterminated quote or escape in command: %s", cmd)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,58 @@
+package docker_drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+func TestParsePortMappings(t *testing.T) {
+	exposed, bindings, err := ParsePortMappings([]string{"8080:80", "127.0.0.1:5353:53/udp", "9000", "[::1]:8443:443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := container.PortMap{
+		"80/tcp":   {{HostPort: "8080"}},
+		"53/udp":   {{HostIP: "127.0.0.1", HostPort: "5353"}},
+		"9000/tcp": {{}},
+		"443/tcp":  {{HostIP: "::1", HostPort: "8443"}},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Errorf("unexpected bindings %v", bindings)
+	}
+	if len(exposed) != 4 {
+		t.Errorf("expected 4 exposed ports, got %v", exposed)
+	}
+
+	for _, spec := range []string{"80/http", "a:80", "1:2:3:4", "localhost:80:80", "70000"} {
+		if _, _, err = ParsePortMappings([]string{spec}); err == nil {
+			t.Errorf("expected error for %s", spec)
+		}
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want []string
+	}{
+		{"", nil},
+		{"nginx -g 'daemon off;'", []string{"nginx", "-g", "daemon off;"}},
+		{`sh -c "echo \"hi\" $HOME"`, []string{"sh", "-c", `echo "hi" $HOME`}},
+		{`a\ b  ""`, []string{"a b", ""}},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommand(tt.cmd)
+		if err != nil {
+			t.Errorf("SplitCommand(%q) error: %v", tt.cmd, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommand(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+	if _, err := SplitCommand(`echo "unterminated`); err == nil {
+		t.Errorf("expected error for unterminated quote")
+	}
+}
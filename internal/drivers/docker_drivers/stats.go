@@ -0,0 +1,132 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 16:10
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// StatsFn 容器资源统计回调
+type StatsFn func(stats *ContainerStatsData)
+
+// ContainerStatsData 一次采样计算后的容器资源使用情况
+type ContainerStatsData struct {
+	CPUPercent    float64
+	MemoryUsage   uint64
+	MemoryLimit   uint64
+	MemoryPercent float64
+	NetRx         uint64
+	NetTx         uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	PIDs          uint64
+	Read          time.Time
+}
+
+// StreamContainerStats 持续读取容器资源统计，直至 ctx 取消或容器停止
+func StreamContainerStats(ctx context.Context, containerID string, fn StatsFn) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	resp, err := cli.ContainerStats(ctx, containerID, true)
+	if err != nil {
+		return fmt.Errorf("failed to get stats for container %s: %w", containerID, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var raw container.StatsResponse
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read stats for container %s: %w", containerID, err)
+		}
+		fn(CalculateStats(&raw, resp.OSType))
+	}
+}
+
+// CalculateStats 按 docker stats 的算法计算 CPU、内存、网络和磁盘使用情况
+func CalculateStats(raw *container.StatsResponse, osType string) *ContainerStatsData {
+	stats := &ContainerStatsData{
+		Read: raw.Read,
+		PIDs: raw.PidsStats.Current,
+	}
+	if osType == "windows" {
+		stats.CPUPercent = windowsCPUPercent(raw)
+		stats.MemoryUsage = raw.MemoryStats.PrivateWorkingSet
+	} else {
+		stats.CPUPercent = unixCPUPercent(raw)
+		stats.MemoryUsage = memoryUsageNoCache(&raw.MemoryStats)
+		stats.MemoryLimit = raw.MemoryStats.Limit
+		if stats.MemoryLimit > 0 {
+			stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+		}
+	}
+	for _, n := range raw.Networks {
+		stats.NetRx += n.RxBytes
+		stats.NetTx += n.TxBytes
+	}
+	for _, entry := range raw.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+	if osType == "windows" {
+		stats.BlockRead = raw.StorageStats.ReadSizeBytes
+		stats.BlockWrite = raw.StorageStats.WriteSizeBytes
+	}
+	return stats
+}
+
+func unixCPUPercent(raw *container.StatsResponse) float64 {
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(raw.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+func windowsCPUPercent(raw *container.StatsResponse) float64 {
+	// Windows 下 CPU 时间以 100ns 为单位，按采样间隔和处理器数量折算
+	interval := raw.Read.Sub(raw.PreRead).Nanoseconds()
+	possible := uint64(interval/100) * uint64(raw.NumProcs)
+	used := raw.CPUStats.CPUUsage.TotalUsage - raw.PreCPUStats.CPUUsage.TotalUsage
+	if possible == 0 || raw.CPUStats.CPUUsage.TotalUsage < raw.PreCPUStats.CPUUsage.TotalUsage {
+		return 0
+	}
+	return float64(used) / float64(possible) * 100
+}
+
+// memoryUsageNoCache 去掉页缓存后的内存使用量，cgroup v1 和 v2 的字段名不同
+func memoryUsageNoCache(mem *container.MemoryStats) uint64 {
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if v, ok := mem.Stats[key]; ok && v < mem.Usage {
+			return mem.Usage - v
+		}
+	}
+	return mem.Usage
+}
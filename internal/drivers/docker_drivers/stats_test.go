@@ -0,0 +1,58 @@
+package docker_drivers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+func TestCalculateStats(t *testing.T) {
+	raw := &container.StatsResponse{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 300},
+			SystemUsage: 2000,
+			OnlineCPUs:  4,
+		},
+		PreCPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 100},
+			SystemUsage: 1000,
+		},
+		MemoryStats: container.MemoryStats{
+			Usage: 600,
+			Limit: 1000,
+			Stats: map[string]uint64{"inactive_file": 100},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 10, TxBytes: 20},
+			"eth1": {RxBytes: 1, TxBytes: 2},
+		},
+		BlkioStats: container.BlkioStats{
+			IoServiceBytesRecursive: []container.BlkioStatEntry{
+				{Op: "Read", Value: 5},
+				{Op: "Write", Value: 7},
+				{Op: "read", Value: 1},
+			},
+		},
+	}
+	stats := CalculateStats(raw, "linux")
+	if math.Abs(stats.CPUPercent-80) > 1e-9 {
+		t.Errorf("CPUPercent = %v, want 80", stats.CPUPercent)
+	}
+	if stats.MemoryUsage != 500 || stats.MemoryLimit != 1000 || stats.MemoryPercent != 50 {
+		t.Errorf("memory = %d/%d (%v%%), want 500/1000 (50%%)", stats.MemoryUsage, stats.MemoryLimit, stats.MemoryPercent)
+	}
+	if stats.NetRx != 11 || stats.NetTx != 22 {
+		t.Errorf("net = %d/%d, want 11/22", stats.NetRx, stats.NetTx)
+	}
+	if stats.BlockRead != 6 || stats.BlockWrite != 7 {
+		t.Errorf("block = %d/%d, want 6/7", stats.BlockRead, stats.BlockWrite)
+	}
+
+	// 首次采样没有 precpu 数据时 CPU 为 0
+	raw.PreCPUStats = container.CPUStats{}
+	raw.CPUStats.SystemUsage = 0
+	if got := CalculateStats(raw, "linux").CPUPercent; got != 0 {
+		t.Errorf("CPUPercent without previous sample = %v, want 0", got)
+	}
+}
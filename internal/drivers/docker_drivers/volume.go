@@ -0,0 +1,124 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 10:40
+ */
+
+package docker_drivers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/api/types/volume"
+)
+
+// VolumeData 数据卷列表中的一行
+type VolumeData struct {
+	Name       string
+	Driver     string
+	Scope      string
+	Mountpoint string
+	CreatedAt  string
+	Containers []string // 挂载该数据卷的容器名称
+}
+
+// ListVolumes 获取数据卷列表，并统计每个数据卷被哪些容器挂载
+func ListVolumes() ([]*VolumeData, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	resp, err := cli.VolumeList(context.Background(), volume.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	mountedBy := make(map[string][]string)
+	for i := range containers {
+		for _, m := range containers[i].Mounts {
+			if m.Type == mount.TypeVolume && m.Name != "" {
+				mountedBy[m.Name] = append(mountedBy[m.Name], containerName(&containers[i]))
+			}
+		}
+	}
+
+	list := make([]*VolumeData, 0, len(resp.Volumes))
+	for _, item := range resp.Volumes {
+		if item == nil {
+			continue
+		}
+		list = append(list, &VolumeData{
+			Name:       item.Name,
+			Driver:     item.Driver,
+			Scope:      item.Scope,
+			Mountpoint: item.Mountpoint,
+			CreatedAt:  item.CreatedAt,
+			Containers: mountedBy[item.Name],
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// InspectVolume 获取数据卷详情
+func InspectVolume(name string) (*volume.Volume, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	volumeInfo, err := cli.VolumeInspect(context.Background(), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect volume %s: %w", name, err)
+	}
+	return &volumeInfo, nil
+}
+
+// CreateVolume 创建数据卷，name 为空时由 Docker 生成
+func CreateVolume(name, driver string) (string, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	vol, err := cli.VolumeCreate(context.Background(), volume.CreateOptions{
+		Name:   name,
+		Driver: driver,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create volume %s: %w", name, err)
+	}
+	return vol.Name, nil
+}
+
+// RemoveVolume 删除数据卷
+func RemoveVolume(name string, force bool) error {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	if err = cli.VolumeRemove(context.Background(), name, force); err != nil {
+		return fmt.Errorf("failed to remove volume %s: %w", name, err)
+	}
+	return nil
+}
+
+// PruneVolumes 清理未被任何容器使用的数据卷，返回删除数量和回收空间
+func PruneVolumes() (int, uint64, error) {
+	cli, err := GetDockerClient()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get Docker client: %w", err)
+	}
+	report, err := cli.VolumesPrune(context.Background(), filters.NewArgs())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune volumes: %w", err)
+	}
+	return len(report.VolumesDeleted), report.SpaceReclaimed, nil
+}
@@ -0,0 +1,155 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/20 10:05
+ */
+
+package redis_drivers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// DeleteBatchSize 批量删除时每批 UNLINK 的键数量
+	DeleteBatchSize = 500
+	// scanCount 每次 SCAN 的建议返回数量
+	scanCount = 500
+)
+
+// DeleteProgressFn 批量删除进度回调，deleted 为已删除的键数量
+type DeleteProgressFn func(deleted int64)
+
+// EscapePattern 转义键名中的 glob 特殊字符，使其在 SCAN MATCH 中按字面匹配
+func EscapePattern(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GroupPattern 键分组（如 user:1）下所有键的匹配模式
+func GroupPattern(prefix string) string {
+	return EscapePattern(prefix) + ":*"
+}
+
+// PreviewPattern 统计匹配模式的键数量，并返回最多 sampleSize 个示例键
+func (_this *RedisClient) PreviewPattern(pattern string, sampleSize int) (int64, []string, error) {
+	if pattern == "" {
+		return 0, nil, fmt.Errorf("pattern cannot be empty")
+	}
+	var count int64
+	var sample []string
+	err := _this.scanKeys(pattern, func(keys []string) error {
+		count += int64(len(keys))
+		for _, key := range keys {
+			if len(sample) >= sampleSize {
+				break
+			}
+			sample = append(sample, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return count, sample, nil
+}
+
+// unlinkKeys 使用 pipeline 批量 UNLINK 指定的键，返回实际删除的数量
+func (_this *RedisClient) unlinkKeys(keys []string) (int64, error) {
+	cmds, err := _this.rdb.Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Unlink(context.Background(), key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to unlink keys: %w", err)
+	}
+	var deleted int64
+	for _, cmd := range cmds {
+		if intCmd, ok := cmd.(*redis.IntCmd); ok {
+			deleted += intCmd.Val()
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteKey 精确删除指定键
+func (_this *RedisClient) DeleteKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
+	}
+	if err := _this.rdb.Unlink(context.Background(), key).Err(); err != nil {
+		return fmt.Errorf("failed to delete key %s: %w", key, err)
+	}
+	slog.Info("Key deleted", "db", _this.dbNum, "key", key)
+	return nil
+}
+
+// DeleteByPattern 按匹配模式分批删除键，每删除一批回调一次进度
+func (_this *RedisClient) DeleteByPattern(pattern string, progress DeleteProgressFn) (int64, error) {
+	if pattern == "" {
+		return 0, fmt.Errorf("pattern cannot be empty")
+	}
+	var deleted int64
+	batch := make([]string, 0, DeleteBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := _this.unlinkKeys(batch)
+		if err != nil {
+			return err
+		}
+		deleted += n
+		batch = batch[:0]
+		if progress != nil {
+			progress(deleted)
+		}
+		return nil
+	}
+	err := _this.scanKeys(pattern, func(keys []string) error {
+		for _, key := range keys {
+			batch = append(batch, key)
+			if len(batch) >= DeleteBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	slog.Info("Keys deleted by pattern", "db", _this.dbNum, "pattern", pattern, "deleted", deleted)
+	return deleted, err
+}
+
+// DeleteKeyGroup 删除键分组下的所有键，同名键本身也一并删除
+func (_this *RedisClient) DeleteKeyGroup(prefix string, progress DeleteProgressFn) (int64, error) {
+	if prefix == "" {
+		return 0, fmt.Errorf("key group cannot be empty")
+	}
+	deleted, err := _this.unlinkKeys([]string{prefix})
+	if err != nil {
+		return 0, err
+	}
+	n, err := _this.DeleteByPattern(GroupPattern(prefix), func(d int64) {
+		if progress != nil {
+			progress(deleted + d)
+		}
+	})
+	return deleted + n, err
+}
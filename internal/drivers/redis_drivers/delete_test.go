@@ -0,0 +1,18 @@
+package redis_drivers
+
+import "testing"
+
+func TestGroupPattern(t *testing.T) {
+	cases := map[string]string{
+		"user:1":    `user:1:*`,
+		"cache*":    `cache\*:*`,
+		"a?b":       `a\?b:*`,
+		"tag[1]":    `tag\[1\]:*`,
+		`path\name`: `path\\name:*`,
+	}
+	for prefix, want := range cases {
+		if got := GroupPattern(prefix); got != want {
+			t.Errorf("GroupPattern(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
@@ -7,15 +7,18 @@ package redis_drivers
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/ssh_drivers"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -25,6 +28,8 @@ type RedisData struct {
 	KetType  string
 	KeyTTL   int64
 	KeyValue string
+	// 集合类型键的第一页数据，string 类型为 nil
+	KeyValues *RedisValue
 }
 
 var connMap sync.Map
@@ -34,27 +39,95 @@ func connMapKey(name string, db int) string {
 }
 
 type RedisClient struct {
-	dbNum  int // 数据库编号
-	rdb    *redis.Client
+	dbNum  int                     // 数据库编号
+	rdb    redis.UniversalClient   // 单机、集群或哨兵客户端
 	config *config.RedisConnConfig // Redis连接配置
 }
 
-func _initRedis(cfg *config.RedisConnConfig, dbNum int) *RedisClient {
-	options := &redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password: cfg.Password,
-		DB:       dbNum,
+func _initRedis(cfg *config.RedisConnConfig, dbNum int) (*RedisClient, error) {
+	// 不指定 ServerName，由 tls 按实际连接的节点地址校验证书
+	tlsConfig, err := cfg.TLS.Build("")
+	if err != nil {
+		return nil, err
 	}
-	if cfg.UserName != "" {
-		options.Username = cfg.UserName
+	dialer := sshDialer(cfg.SSHHost, tlsConfig)
+	var rdb redis.UniversalClient
+	switch cfg.GetMode() {
+	case config.RedisModeCluster:
+		// 集群模式只有 db0
+		rdb = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     cfg.SeedAddrs(),
+			Username:  cfg.UserName,
+			Password:  cfg.Password,
+			TLSConfig: tlsConfig,
+			Dialer:    dialer,
+		})
+		dbNum = 0
+	case config.RedisModeSentinel:
+		rdb = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.SeedAddrs(),
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.UserName,
+			Password:         cfg.Password,
+			DB:               dbNum,
+			TLSConfig:        tlsConfig,
+			Dialer:           dialer,
+		})
+	default:
+		options := &redis.Options{
+			Addr:      fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Password:  cfg.Password,
+			DB:        dbNum,
+			TLSConfig: tlsConfig,
+			Dialer:    dialer,
+		}
+		if cfg.UserName != "" {
+			options.Username = cfg.UserName
+		}
+		rdb = redis.NewClient(options)
 	}
-	rdb := redis.NewClient(options)
 	rdbClient := &RedisClient{
 		rdb:    rdb,
 		config: cfg,
 		dbNum:  dbNum,
 	}
-	return rdbClient
+	return rdbClient, nil
+}
+
+// sshDialer 经由 SSH 隧道连接 Redis 节点，未配置隧道时返回 nil 使用默认拨号
+// 自定义 Dialer 后 go-redis 不再处理 TLS，需要在隧道连接上自行握手
+func sshDialer(alias string, tlsConfig *tls.Config) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if alias == "" {
+		return nil
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := ssh_drivers.Dial(ctx, alias, network, addr)
+		if err != nil || tlsConfig == nil {
+			return conn, err
+		}
+		cfg := tlsConfig.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName, _, _ = net.SplitHostPort(addr)
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
+
+// ResetConnect 关闭并移除指定连接的所有缓存客户端，连接配置修改后调用
+func ResetConnect(name string) {
+	connMap.Range(func(key, value any) bool {
+		if strings.HasPrefix(key.(string), name+"@") {
+			_ = value.(*RedisClient).rdb.Close()
+			connMap.Delete(key)
+		}
+		return true
+	})
 }
 
 func GetConnect(cfg *config.RedisConnConfig, dbNum int) (*RedisClient, error) {
@@ -79,7 +152,10 @@ func GetConnectOrInit(cfg *config.RedisConnConfig, dbNum int) (*RedisClient, err
 		return nil, fmt.Errorf("invalid type stored in connMap for key %s", key)
 	}
 
-	iDriver := _initRedis(cfg, dbNum)
+	iDriver, err := _initRedis(cfg, dbNum)
+	if err != nil {
+		return nil, err
+	}
 	connMap.Store(key, iDriver)
 	return GetConnect(cfg, dbNum)
 }
@@ -88,7 +164,13 @@ func TestConnection(cfg *config.RedisConnConfig) error {
 	if cfg == nil {
 		return fmt.Errorf("redis connection configuration is nil")
 	}
-	iDriver := _initRedis(cfg, 0)
+	iDriver, err := _initRedis(cfg, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = iDriver.rdb.Close()
+	}()
 	pong, err := iDriver.rdb.Ping(context.Background()).Result()
 	if err != nil {
 		return fmt.Errorf("failed to get sql.DB from gorm.DB: %w", err)
@@ -102,6 +184,9 @@ func TestConnection(cfg *config.RedisConnConfig) error {
 }
 
 func (_this *RedisClient) ListDB() (int, error) {
+	if _this.config.IsCluster() {
+		return 1, nil
+	}
 	dbs, err := _this.rdb.ConfigGet(context.Background(), "databases").Result()
 	if err != nil {
 		return 0, fmt.Errorf("failed to list Redis databases: %w", err)
@@ -119,31 +204,62 @@ func (_this *RedisClient) ListDB() (int, error) {
 
 // GetRecords 获取指定数据库的记录
 func (_this *RedisClient) GetRecords(key string) ([]string, error) {
-	var cursor uint64
 	var allKeys = make([]string, 0)
 	var search = "*"
 	if key != "" {
 		search = "*" + key + "*"
 	}
+	err := _this.scanKeys(search, func(keys []string) error {
+		allKeys = append(allKeys, keys...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Keys retrieved successfully", "db", _this.dbNum, "search", search, "keys", allKeys)
+	return allKeys, nil
+}
+
+// scanKeys 按匹配模式遍历键，每批回调一次；集群模式下遍历所有主节点
+func (_this *RedisClient) scanKeys(pattern string, fn func(keys []string) error) error {
+	if cluster, ok := _this.rdb.(*redis.ClusterClient); ok {
+		var mu sync.Mutex
+		return cluster.ForEachMaster(context.Background(), func(ctx context.Context, client *redis.Client) error {
+			return scanNode(ctx, client, pattern, func(keys []string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return fn(keys)
+			})
+		})
+	}
+	return scanNode(context.Background(), _this.rdb, pattern, fn)
+}
+
+func scanNode(ctx context.Context, client redis.Cmdable, pattern string, fn func(keys []string) error) error {
+	var cursor uint64
 	for {
-		keys, nextCursor, err := _this.rdb.Scan(context.Background(), cursor, search, 100).Result()
+		keys, nextCursor, err := client.Scan(ctx, cursor, pattern, scanCount).Result()
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan Redis keys: %w", err)
+			return fmt.Errorf("failed to scan Redis keys: %w", err)
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
 		}
-		allKeys = append(allKeys, keys...)
-
 		if nextCursor == 0 {
-			break
+			return nil
 		}
 		cursor = nextCursor
 	}
-
-	slog.Info("Keys retrieved successfully", "db", _this.dbNum, "search", search, "keys", allKeys)
-	return allKeys, nil
 }
 
 // GetHasKeyDbNum 获取有 key 的 Redis 数据库编号（如 0、1、2...）
 func (_this *RedisClient) GetHasKeyDbNum() ([]int, error) {
+	if _this.config.IsCluster() {
+		return []int{0}, nil
+	}
 	// 使用 INFO keyspace 获取非空数据库信息
 	result, err := _this.rdb.Info(context.Background(), "keyspace").Result()
 	if err != nil {
@@ -216,45 +332,40 @@ func (_this *RedisClient) GetKeyTTL(key string) (int64, error) {
 	return int64(ttl.Seconds()), nil
 }
 
-// DeleteKey 删除指定键
-func (_this *RedisClient) DeleteKey(key string) {
-	keys, err := _this.GetRecords(key)
-	if err != nil {
-		slog.Error("Failed to get keys for deletion", "error", err)
-		return
-	}
-	for i := 0; i < len(keys); i++ {
-		_this.rdb.Del(context.Background(), keys[i])
-	}
-}
-
 // GetKeyData 获取指定键的详细数据
 func (_this *RedisClient) GetKeyData(key string) (*RedisData, error) {
 	if key == "" {
 		return nil, fmt.Errorf("key cannot be empty")
 	}
 
-	keyValue, err := _this.GetKeyValue(key)
+	keyType, err := _this.GetKeyType(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get key value: %w", err)
+		return nil, fmt.Errorf("failed to get key type: %w", err)
+	}
+	if keyType == "none" {
+		return nil, fmt.Errorf("key %s does not exist", key)
 	}
 
-	keyType, err := _this.GetKeyType(key)
+	data := &RedisData{
+		KeyName: key,
+		KetType: keyType,
+	}
+	// 根据类型读取值，集合类型只读取第一页
+	if keyType == RedisTypeString {
+		data.KeyValue, err = _this.GetKeyValue(key)
+	} else {
+		data.KeyValues, err = _this.GetKeyValues(key, keyType, "", DefaultValuePageSize)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get key type: %w", err)
+		return nil, fmt.Errorf("failed to get key value: %w", err)
 	}
 
-	keyTTL, err := _this.GetKeyTTL(key)
+	data.KeyTTL, err = _this.GetKeyTTL(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get key TTL: %w", err)
 	}
 
-	return &RedisData{
-		KeyName:  key,
-		KetType:  keyType,
-		KeyTTL:   keyTTL,
-		KeyValue: keyValue,
-	}, nil
+	return data, nil
 }
 
 // EditKeyData 编辑指定键的数据
@@ -263,6 +374,9 @@ func (_this *RedisClient) EditKeyData(key string, data *RedisData) error {
 	if key == "" || data == nil {
 		return fmt.Errorf("key and data cannot be empty")
 	}
+	if data.KetType != RedisTypeString {
+		return fmt.Errorf("only string keys can be edited, got %s", data.KetType)
+	}
 
 	// 设置键的值
 	if err := _this.rdb.Set(context.Background(), key, data.KeyValue, 0).Err(); err != nil {
@@ -286,9 +400,13 @@ func (_this *RedisClient) CreateKeyData(data *RedisData) error {
 		return fmt.Errorf("data and key name cannot be empty")
 	}
 
-	// 设置键的值
-	if err := _this.rdb.Set(context.Background(), data.KeyName, data.KeyValue, 0).Err(); err != nil {
-		return fmt.Errorf("failed to set key value: %w", err)
+	// 设置键的值，集合类型按类型写入初始元素
+	if data.KetType == "" || data.KetType == RedisTypeString {
+		if err := _this.rdb.Set(context.Background(), data.KeyName, data.KeyValue, 0).Err(); err != nil {
+			return fmt.Errorf("failed to set key value: %w", err)
+		}
+	} else if err := _this.createCollection(data); err != nil {
+		return fmt.Errorf("failed to create %s key: %w", data.KetType, err)
 	}
 
 	// 设置键的过期时间
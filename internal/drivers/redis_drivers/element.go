@@ -0,0 +1,266 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/19 15:40
+ */
+
+package redis_drivers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// RedisKeyTypeList 可新建的键类型
+var RedisKeyTypeList = []string{
+	RedisTypeString,
+	RedisTypeHash,
+	RedisTypeList,
+	RedisTypeSet,
+	RedisTypeZSet,
+	RedisTypeStream,
+}
+
+// HashSetField 设置 hash 字段
+func (_this *RedisClient) HashSetField(key, field, value string) error {
+	if err := _this.rdb.HSet(context.Background(), key, field, value).Err(); err != nil {
+		return fmt.Errorf("failed to set hash field: %w", err)
+	}
+	return nil
+}
+
+// HashRenameField 修改 hash 字段名及值
+func (_this *RedisClient) HashRenameField(key, oldField, newField, value string) error {
+	_, err := _this.rdb.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HDel(context.Background(), key, oldField)
+		pipe.HSet(context.Background(), key, newField, value)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename hash field: %w", err)
+	}
+	return nil
+}
+
+// HashDeleteField 删除 hash 字段
+func (_this *RedisClient) HashDeleteField(key, field string) error {
+	if err := _this.rdb.HDel(context.Background(), key, field).Err(); err != nil {
+		return fmt.Errorf("failed to delete hash field: %w", err)
+	}
+	return nil
+}
+
+// ListPush 向 list 头部或尾部插入元素
+func (_this *RedisClient) ListPush(key, value string, head bool) error {
+	var err error
+	if head {
+		err = _this.rdb.LPush(context.Background(), key, value).Err()
+	} else {
+		err = _this.rdb.RPush(context.Background(), key, value).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to push list element: %w", err)
+	}
+	return nil
+}
+
+// ListPop 从 list 头部或尾部弹出元素
+func (_this *RedisClient) ListPop(key string, head bool) (string, error) {
+	var cmd *redis.StringCmd
+	if head {
+		cmd = _this.rdb.LPop(context.Background(), key)
+	} else {
+		cmd = _this.rdb.RPop(context.Background(), key)
+	}
+	val, err := cmd.Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to pop list element: %w", err)
+	}
+	return val, nil
+}
+
+// ListSetIndex 设置 list 指定下标的元素
+func (_this *RedisClient) ListSetIndex(key string, index int64, value string) error {
+	if err := _this.rdb.LSet(context.Background(), key, index, value).Err(); err != nil {
+		return fmt.Errorf("failed to set list element: %w", err)
+	}
+	return nil
+}
+
+// ListRemoveIndex 删除 list 指定下标的元素，先用占位值覆盖再删除占位值
+// 占位值每次随机生成，避免与 list 中已有的元素相同时删错元素
+func (_this *RedisClient) ListRemoveIndex(key string, index int64) error {
+	placeholder := "__lxz_removed_" + uuid.NewString()
+	_, err := _this.rdb.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.LSet(context.Background(), key, index, placeholder)
+		pipe.LRem(context.Background(), key, 1, placeholder)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove list element: %w", err)
+	}
+	return nil
+}
+
+// SetAddMember 向 set 添加成员
+func (_this *RedisClient) SetAddMember(key, member string) error {
+	if err := _this.rdb.SAdd(context.Background(), key, member).Err(); err != nil {
+		return fmt.Errorf("failed to add set member: %w", err)
+	}
+	return nil
+}
+
+// SetReplaceMember 替换 set 成员
+func (_this *RedisClient) SetReplaceMember(key, oldMember, newMember string) error {
+	_, err := _this.rdb.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.SRem(context.Background(), key, oldMember)
+		pipe.SAdd(context.Background(), key, newMember)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace set member: %w", err)
+	}
+	return nil
+}
+
+// SetRemoveMember 删除 set 成员
+func (_this *RedisClient) SetRemoveMember(key, member string) error {
+	if err := _this.rdb.SRem(context.Background(), key, member).Err(); err != nil {
+		return fmt.Errorf("failed to remove set member: %w", err)
+	}
+	return nil
+}
+
+// ZSetAddMember 向 zset 添加成员，成员已存在时更新分数
+func (_this *RedisClient) ZSetAddMember(key, member string, score float64) error {
+	err := _this.rdb.ZAdd(context.Background(), key, &redis.Z{Score: score, Member: member}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add zset member: %w", err)
+	}
+	return nil
+}
+
+// ZSetRemoveMember 删除 zset 成员
+func (_this *RedisClient) ZSetRemoveMember(key, member string) error {
+	if err := _this.rdb.ZRem(context.Background(), key, member).Err(); err != nil {
+		return fmt.Errorf("failed to remove zset member: %w", err)
+	}
+	return nil
+}
+
+// StreamAdd 向 stream 追加消息，id 为空时由 Redis 生成
+func (_this *RedisClient) StreamAdd(key, id string, fields []string) (string, error) {
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return "", fmt.Errorf("stream message requires field and value pairs")
+	}
+	if id == "" {
+		id = "*"
+	}
+	newID, err := _this.rdb.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: key,
+		ID:     id,
+		Values: fields,
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to add stream message: %w", err)
+	}
+	return newID, nil
+}
+
+// StreamDelete 删除 stream 消息
+func (_this *RedisClient) StreamDelete(key, id string) error {
+	if err := _this.rdb.XDel(context.Background(), key, id).Err(); err != nil {
+		return fmt.Errorf("failed to delete stream message: %w", err)
+	}
+	return nil
+}
+
+// ParsePairs 解析每行一个 name=value 的文本，用于 hash 字段、zset 成员和 stream 消息
+// 只去掉名称两端的空白，值原样保留，空行忽略
+func ParsePairs(text string) ([]string, error) {
+	var pairs []string
+	for _, line := range splitLines(text) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q, expected name=value", line)
+		}
+		pairs = append(pairs, strings.TrimSpace(name), value)
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("value cannot be empty")
+	}
+	return pairs, nil
+}
+
+// ParseLines 解析每行一个元素的文本，用于 list 和 set
+// 元素原样保留，空行也是一个元素（空串）
+func ParseLines(text string) ([]string, error) {
+	if text == "" {
+		return nil, fmt.Errorf("value cannot be empty")
+	}
+	return splitLines(text), nil
+}
+
+// splitLines 按行拆分，末尾的一个换行不产生空行，兼容 \r\n 换行
+func splitLines(text string) []string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// createCollection 按类型写入集合类型键的初始元素
+func (_this *RedisClient) createCollection(data *RedisData) error {
+	ctx := context.Background()
+	switch data.KetType {
+	case RedisTypeHash:
+		pairs, err := ParsePairs(data.KeyValue)
+		if err != nil {
+			return err
+		}
+		return _this.rdb.HSet(ctx, data.KeyName, pairs).Err()
+	case RedisTypeList:
+		lines, err := ParseLines(data.KeyValue)
+		if err != nil {
+			return err
+		}
+		return _this.rdb.RPush(ctx, data.KeyName, lines).Err()
+	case RedisTypeSet:
+		lines, err := ParseLines(data.KeyValue)
+		if err != nil {
+			return err
+		}
+		return _this.rdb.SAdd(ctx, data.KeyName, lines).Err()
+	case RedisTypeZSet:
+		pairs, err := ParsePairs(data.KeyValue)
+		if err != nil {
+			return err
+		}
+		members := make([]*redis.Z, 0, len(pairs)/2)
+		for i := 0; i < len(pairs); i += 2 {
+			score, err := strconv.ParseFloat(pairs[i+1], 64)
+			if err != nil {
+				return fmt.Errorf("invalid score %q for member %q", pairs[i+1], pairs[i])
+			}
+			members = append(members, &redis.Z{Score: score, Member: pairs[i]})
+		}
+		return _this.rdb.ZAdd(ctx, data.KeyName, members...).Err()
+	case RedisTypeStream:
+		pairs, err := ParsePairs(data.KeyValue)
+		if err != nil {
+			return err
+		}
+		_, err = _this.StreamAdd(data.KeyName, "", pairs)
+		return err
+	default:
+		return fmt.Errorf("unsupported key type: %s", data.KetType)
+	}
+}
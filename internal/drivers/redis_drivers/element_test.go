@@ -0,0 +1,99 @@
+package redis_drivers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestParsePairs(t *testing.T) {
+	got, err := ParsePairs(" name = lxz \n\nurl=a=b\r\nempty=\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 只按第一个等号拆分，值中可以包含等号；只去掉名称两端的空白
+	if want := []string{"name", " lxz ", "url", "a=b", "empty", ""}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for _, text := range []string{"", " \n ", "name=lxz\nnovalue"} {
+		if _, err = ParsePairs(text); err == nil {
+			t.Errorf("ParsePairs(%q): expected an error", text)
+		}
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	got, err := ParseLines(" a \n\nb c\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 元素原样保留，空行是一个空串元素，末尾的换行不产生元素
+	if want := []string{" a ", "", "b c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, err = ParseLines("\n"); err != nil || !reflect.DeepEqual(got, []string{""}) {
+		t.Fatalf("got %q, err %v, want a single empty member", got, err)
+	}
+	if _, err = ParseLines(""); err == nil {
+		t.Fatal("expected an error for empty input")
+	}
+}
+
+// recordHook 记录事务中的命令，并在发送前中止，测试不需要 Redis 服务
+type recordHook struct {
+	cmds []redis.Cmder
+}
+
+var errAborted = errors.New("aborted")
+
+func (h *recordHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return ctx, errAborted
+}
+
+func (h *recordHook) AfterProcess(context.Context, redis.Cmder) error {
+	return nil
+}
+
+func (h *recordHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	h.cmds = append(h.cmds, cmds...)
+	return ctx, errAborted
+}
+
+func (h *recordHook) AfterProcessPipeline(context.Context, []redis.Cmder) error {
+	return nil
+}
+
+func TestListRemoveIndexPlaceholder(t *testing.T) {
+	hook := &recordHook{}
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	rdb.AddHook(hook)
+	client := &RedisClient{rdb: rdb}
+
+	var placeholders []any
+	for range 2 {
+		hook.cmds = nil
+		if err := client.ListRemoveIndex("k", 1); !errors.Is(err, errAborted) {
+			t.Fatalf("expected the hook error, got %v", err)
+		}
+		var set, rem []any
+		for _, cmd := range hook.cmds {
+			switch cmd.Name() {
+			case "lset":
+				set = cmd.Args()
+			case "lrem":
+				rem = cmd.Args()
+			}
+		}
+		// LSET key index value / LREM key count value，删除的正是写入的占位值
+		if len(set) != 4 || len(rem) != 4 || set[3] != rem[3] {
+			t.Fatalf("unexpected commands %v %v", set, rem)
+		}
+		placeholders = append(placeholders, set[3])
+	}
+	if placeholders[0] == placeholders[1] {
+		t.Fatalf("expected a new placeholder per call, got %v twice", placeholders[0])
+	}
+}
@@ -0,0 +1,274 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/19 10:20
+ */
+
+package redis_drivers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis 键类型
+const (
+	RedisTypeString = "string"
+	RedisTypeHash   = "hash"
+	RedisTypeList   = "list"
+	RedisTypeSet    = "set"
+	RedisTypeZSet   = "zset"
+	RedisTypeStream = "stream"
+)
+
+// DefaultValuePageSize 集合类型每页读取的元素数量
+const DefaultValuePageSize int64 = 100
+
+// RedisValue 集合类型键的一页数据
+type RedisValue struct {
+	Columns []string   // 表头
+	Rows    [][]string // 每行数据，与表头一一对应
+	Total   int64      // 元素总数
+	Next    string     // 下一页游标，为空表示没有更多数据
+}
+
+// GetKeyValues 按类型分页读取集合类型键的值，cursor 为空表示读取第一页
+func (_this *RedisClient) GetKeyValues(key, keyType, cursor string, count int64) (*RedisValue, error) {
+	if count <= 0 {
+		count = DefaultValuePageSize
+	}
+	switch keyType {
+	case RedisTypeHash:
+		return _this.getHashValues(key, cursor, count)
+	case RedisTypeList:
+		return _this.getListValues(key, cursor, count)
+	case RedisTypeSet:
+		return _this.getSetValues(key, cursor, count)
+	case RedisTypeZSet:
+		return _this.getZSetValues(key, cursor, count)
+	case RedisTypeStream:
+		return _this.getStreamValues(key, cursor, count)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", keyType)
+	}
+}
+
+// getHashValues 元素较少时使用 HGETALL，否则使用 HSCAN 分页
+func (_this *RedisClient) getHashValues(key, cursor string, count int64) (*RedisValue, error) {
+	ctx := context.Background()
+	total, err := _this.rdb.HLen(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash length: %w", err)
+	}
+	value := &RedisValue{Columns: []string{"Field", "Value"}, Total: total}
+	if cursor == "" && total <= count {
+		fields, err := _this.rdb.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hash fields: %w", err)
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value.Rows = append(value.Rows, []string{name, fields[name]})
+		}
+		return value, nil
+	}
+
+	scanCursor, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	items, next, err := _this.rdb.HScan(ctx, key, scanCursor, "", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan hash fields: %w", err)
+	}
+	for i := 0; i+1 < len(items); i += 2 {
+		value.Rows = append(value.Rows, []string{items[i], items[i+1]})
+	}
+	if next != 0 {
+		value.Next = strconv.FormatUint(next, 10)
+	}
+	return value, nil
+}
+
+// getListValues 使用 LRANGE 按下标分页
+func (_this *RedisClient) getListValues(key, cursor string, count int64) (*RedisValue, error) {
+	ctx := context.Background()
+	total, err := _this.rdb.LLen(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list length: %w", err)
+	}
+	start, err := parseOffset(cursor)
+	if err != nil {
+		return nil, err
+	}
+	items, err := _this.rdb.LRange(ctx, key, start, start+count-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list range: %w", err)
+	}
+	value := &RedisValue{Columns: []string{"Index", "Value"}, Total: total}
+	for i, item := range items {
+		value.Rows = append(value.Rows, []string{strconv.FormatInt(start+int64(i), 10), item})
+	}
+	if next := start + int64(len(items)); next < total && len(items) > 0 {
+		value.Next = strconv.FormatInt(next, 10)
+	}
+	return value, nil
+}
+
+// getSetValues 元素较少时使用 SMEMBERS，否则使用 SSCAN 分页
+func (_this *RedisClient) getSetValues(key, cursor string, count int64) (*RedisValue, error) {
+	ctx := context.Background()
+	total, err := _this.rdb.SCard(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get set size: %w", err)
+	}
+	value := &RedisValue{Columns: []string{"Member"}, Total: total}
+	if cursor == "" && total <= count {
+		members, err := _this.rdb.SMembers(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get set members: %w", err)
+		}
+		sort.Strings(members)
+		for _, member := range members {
+			value.Rows = append(value.Rows, []string{member})
+		}
+		return value, nil
+	}
+
+	scanCursor, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	members, next, err := _this.rdb.SScan(ctx, key, scanCursor, "", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan set members: %w", err)
+	}
+	for _, member := range members {
+		value.Rows = append(value.Rows, []string{member})
+	}
+	if next != 0 {
+		value.Next = strconv.FormatUint(next, 10)
+	}
+	return value, nil
+}
+
+// getZSetValues 使用 ZRANGE WITHSCORES 按排名分页
+func (_this *RedisClient) getZSetValues(key, cursor string, count int64) (*RedisValue, error) {
+	ctx := context.Background()
+	total, err := _this.rdb.ZCard(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zset size: %w", err)
+	}
+	start, err := parseOffset(cursor)
+	if err != nil {
+		return nil, err
+	}
+	items, err := _this.rdb.ZRangeWithScores(ctx, key, start, start+count-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zset range: %w", err)
+	}
+	value := &RedisValue{Columns: []string{"Member", "Score"}, Total: total}
+	for _, item := range items {
+		value.Rows = append(value.Rows, []string{
+			fmt.Sprint(item.Member),
+			strconv.FormatFloat(item.Score, 'f', -1, 64),
+		})
+	}
+	if next := start + int64(len(items)); next < total && len(items) > 0 {
+		value.Next = strconv.FormatInt(next, 10)
+	}
+	return value, nil
+}
+
+// getStreamValues 使用 XRANGE 按消息 ID 分页
+func (_this *RedisClient) getStreamValues(key, cursor string, count int64) (*RedisValue, error) {
+	ctx := context.Background()
+	total, err := _this.rdb.XLen(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream length: %w", err)
+	}
+	start := "-"
+	if cursor != "" {
+		start = cursor
+	}
+	messages, err := _this.rdb.XRangeN(ctx, key, start, "+", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream range: %w", err)
+	}
+	value := &RedisValue{Columns: []string{"ID", "Fields"}, Total: total}
+	for _, message := range messages {
+		value.Rows = append(value.Rows, []string{message.ID, formatStreamFields(message)})
+	}
+	if int64(len(messages)) == count {
+		next, err := nextStreamID(messages[len(messages)-1].ID)
+		if err != nil {
+			return nil, err
+		}
+		value.Next = next
+	}
+	return value, nil
+}
+
+// formatStreamFields 将消息字段按字段名排序后拼接为 field=value 形式
+func formatStreamFields(message redis.XMessage) string {
+	names := make([]string, 0, len(message.Values))
+	for name := range message.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%v", name, message.Values[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// nextStreamID 计算紧跟在指定 ID 之后的消息 ID，用于 XRANGE 翻页
+func nextStreamID(id string) (string, error) {
+	ms, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return "", fmt.Errorf("invalid stream id: %s", id)
+	}
+	msNum, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream id: %s", id)
+	}
+	seqNum, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid stream id: %s", id)
+	}
+	if seqNum == ^uint64(0) {
+		return fmt.Sprintf("%d-0", msNum+1), nil
+	}
+	return fmt.Sprintf("%d-%d", msNum, seqNum+1), nil
+}
+
+func parseCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %s", cursor)
+	}
+	return v, nil
+}
+
+func parseOffset(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset: %s", cursor)
+	}
+	return v, nil
+}
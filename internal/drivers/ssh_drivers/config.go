@@ -0,0 +1,174 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/7/31 16:02
+ */
+
+package ssh_drivers
+
+import (
+	"bufio"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+type HostItem struct {
+	Host         string
+	HostName     string
+	User         string
+	Port         string
+	Jump         string
+	Source       string
+	FilePath     string
+	IdentityFile string
+	ProxyCommand string
+}
+
+// 解析配置文件，只返回含 HostName 字段的 Host
+func parseConfigFileWithHostname(path string) ([]HostItem, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+
+	var results []HostItem
+	var currentHosts []string
+	var hostName, user, port, identityFile, proxyCommand, jump string
+	inHostBlock := false
+
+	base := filepath.Base(path)
+
+	saveCurrentHostBlock := func() {
+		if len(currentHosts) > 0 && hostName != "" {
+			for _, h := range currentHosts {
+				results = append(results, HostItem{
+					Host:         h,
+					HostName:     hostName,
+					User:         user,
+					Port:         port,
+					Jump:         jump,
+					IdentityFile: identityFile,
+					ProxyCommand: proxyCommand,
+					Source:       base,
+					FilePath:     path,
+				})
+			}
+		}
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "Host ") {
+			// 保存上一个 Host 块
+			saveCurrentHostBlock()
+
+			// 开启新块
+			currentHosts = strings.Fields(line)[1:]
+			hostName, user, port, identityFile, proxyCommand, jump = "", "", "", "", "", ""
+			inHostBlock = true
+		} else if inHostBlock {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			key := strings.ToLower(fields[0])
+			value := strings.Join(fields[1:], " ")
+			switch key {
+			case "hostname":
+				hostName = value
+			case "user":
+				user = value
+			case "port":
+				port = value
+			case "identityfile":
+				identityFile = value
+			case "proxycommand":
+				proxyCommand = value
+			case "proxyjump":
+				jump = value
+			}
+		}
+	}
+
+	// 保存最后一个块
+	saveCurrentHostBlock()
+	return results, nil
+}
+
+// LoadAllHostsGrouped 读取 ~/.ssh/config 及其 Include 的文件，按来源文件分组返回主机
+func LoadAllHostsGrouped() (map[string][]HostItem, []string, error) {
+	usr, _ := user.Current()
+	mainConfig := filepath.Join(usr.HomeDir, ".ssh", "config")
+
+	queue := []string{mainConfig}
+	visited := make(map[string]bool)
+	sourceOrder := []string{}
+	hostMap := make(map[string][]HostItem)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		items, err := parseConfigFileWithHostname(current)
+		if err == nil && len(items) > 0 {
+			source := filepath.Base(current)
+			hostMap[source] = append(hostMap[source], items...)
+			sourceOrder = append(sourceOrder, source)
+		}
+
+		f, err := os.Open(current)
+		if err != nil {
+			continue
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "Include ") {
+				included := expandIncludes(line)
+				queue = append(queue, included...)
+			}
+		}
+	}
+
+	return hostMap, sourceOrder, nil
+}
+
+func expandIncludes(line string) []string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil
+	}
+	paths := fields[1:]
+	var expanded []string
+	for _, p := range paths {
+		if strings.HasPrefix(p, "~") {
+			if home, err := os.UserHomeDir(); err == nil {
+				p = filepath.Join(home, p[1:])
+			}
+		}
+		matches, err := filepath.Glob(p)
+		if err == nil {
+			expanded = append(expanded, matches...)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,321 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/21 10:30
+ */
+
+package ssh_drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultSSHPort = "22"
+	dialTimeout    = 10 * time.Second
+	// maxJumpDepth ProxyJump 的最大嵌套层数，防止配置循环引用
+	maxJumpDepth = 8
+)
+
+// 按主机别名缓存的 SSH 客户端 alias -> *ssh.Client
+var clientMap sync.Map
+
+// 按别名的建连锁 alias -> *sync.Mutex，同一别名串行化避免并发重复建立隧道，不同主机互不阻塞
+var dialLocks sync.Map
+
+// defaultIdentityFiles 未配置 IdentityFile 时尝试的私钥
+var defaultIdentityFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// FindHost 按别名在 ~/.ssh/config 中查找主机
+func FindHost(alias string) (*HostItem, error) {
+	hostMap, _, err := LoadAllHostsGrouped()
+	if err != nil {
+		return nil, err
+	}
+	for _, items := range hostMap {
+		for i := range items {
+			if items[i].Host == alias {
+				return &items[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("ssh host %s not found in ~/.ssh/config", alias)
+}
+
+// HostAliases 获取 ~/.ssh/config 中所有主机别名
+func HostAliases() []string {
+	hostMap, order, err := LoadAllHostsGrouped()
+	if err != nil {
+		return nil
+	}
+	var aliases []string
+	for _, source := range order {
+		for _, item := range hostMap[source] {
+			aliases = append(aliases, item.Host)
+		}
+	}
+	return aliases
+}
+
+// Dial 通过 SSH 主机别名建立隧道，并经由该主机连接目标地址
+func Dial(ctx context.Context, alias, network, addr string) (net.Conn, error) {
+	client, err := getClient(alias)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.DialContext(ctx, network, addr)
+	if err == nil {
+		return conn, nil
+	}
+	// 隧道可能已断开，重建一次
+	slog.Warn("ssh tunnel dial failed, reconnecting", "alias", alias, "addr", addr, "error", err)
+	dropClient(alias, client)
+	client, err = getClient(alias)
+	if err != nil {
+		return nil, err
+	}
+	conn, err = client.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s via ssh host %s: %w", addr, alias, err)
+	}
+	return conn, nil
+}
+
+func getClient(alias string) (*ssh.Client, error) {
+	if client, ok := clientMap.Load(alias); ok {
+		return client.(*ssh.Client), nil
+	}
+	lock, _ := dialLocks.LoadOrStore(alias, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+	if client, ok := clientMap.Load(alias); ok {
+		return client.(*ssh.Client), nil
+	}
+	client, err := dialAlias(alias, 0)
+	if err != nil {
+		return nil, err
+	}
+	clientMap.Store(alias, client)
+	slog.Info("ssh tunnel established", "alias", alias)
+	return client, nil
+}
+
+func dropClient(alias string, client *ssh.Client) {
+	clientMap.CompareAndDelete(alias, client)
+	_ = client.Close()
+}
+
+// resolveHost 解析别名或 user@host:port 形式的跳板机
+func resolveHost(target string) (*HostItem, error) {
+	if item, err := FindHost(target); err == nil {
+		return item, nil
+	}
+	item := &HostItem{Host: target}
+	hostPort := target
+	if u, rest, ok := strings.Cut(target, "@"); ok {
+		item.User = u
+		hostPort = rest
+	}
+	if host, port, err := net.SplitHostPort(hostPort); err == nil {
+		item.HostName = host
+		item.Port = port
+	} else {
+		item.HostName = hostPort
+	}
+	if item.HostName == "" {
+		return nil, fmt.Errorf("invalid ssh host: %s", target)
+	}
+	return item, nil
+}
+
+// dialAlias 连接指定主机，按 ProxyJump 依次经过跳板机
+func dialAlias(alias string, depth int) (*ssh.Client, error) {
+	if depth > maxJumpDepth {
+		return nil, fmt.Errorf("too many ProxyJump hops for ssh host %s", alias)
+	}
+	item, err := resolveHost(alias)
+	if err != nil {
+		return nil, err
+	}
+	if item.ProxyCommand != "" && item.Jump == "" {
+		return nil, fmt.Errorf("ssh host %s uses ProxyCommand, which is not supported, use ProxyJump instead", alias)
+	}
+
+	var via *ssh.Client
+	if item.Jump != "" && !strings.EqualFold(item.Jump, "none") {
+		for i, hop := range strings.Split(item.Jump, ",") {
+			hop = strings.TrimSpace(hop)
+			if i == 0 {
+				// 第一个跳板机自身可能也配置了 ProxyJump
+				via, err = dialAlias(hop, depth+1)
+			} else {
+				var hopItem *HostItem
+				hopItem, err = resolveHost(hop)
+				if err != nil && via != nil {
+					_ = via.Close()
+				}
+				if err == nil {
+					via, err = connect(hopItem, via)
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect jump host %s: %w", hop, err)
+			}
+		}
+	}
+	return connect(item, via)
+}
+
+// connect 建立到主机的 SSH 连接，via 不为空时经由该连接转发
+// 经由跳板机建立的连接关闭时会一并关闭跳板机的连接，出错时 via 也会被关闭
+func connect(item *HostItem, via *ssh.Client) (*ssh.Client, error) {
+	client, err := _connect(item, via)
+	if via == nil {
+		return client, err
+	}
+	if err != nil {
+		_ = via.Close()
+		return nil, err
+	}
+	go func() {
+		_ = client.Wait()
+		_ = via.Close()
+	}()
+	return client, nil
+}
+
+func _connect(item *HostItem, via *ssh.Client) (*ssh.Client, error) {
+	clientConfig, closeAgent, err := clientConfig(item)
+	if err != nil {
+		return nil, err
+	}
+	// ssh-agent 只在握手认证时使用
+	defer closeAgent()
+	host := item.HostName
+	if host == "" {
+		host = item.Host
+	}
+	port := item.Port
+	if port == "" {
+		port = defaultSSHPort
+	}
+	addr := net.JoinHostPort(host, port)
+
+	if via == nil {
+		client, err := ssh.Dial("tcp", addr, clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect ssh host %s: %w", item.Host, err)
+		}
+		return client, nil
+	}
+	conn, err := via.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ssh host %s: %w", item.Host, err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to connect ssh host %s: %w", item.Host, err)
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// clientConfig 返回的 closeAgent 用于关闭 ssh-agent 的连接，握手完成后调用
+func clientConfig(item *HostItem) (*ssh.ClientConfig, func(), error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	hostKeyCallback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load ~/.ssh/known_hosts: %w", err)
+	}
+
+	userName := item.User
+	if userName == "" {
+		if u, err := user.Current(); err == nil {
+			userName = u.Username
+		}
+	}
+
+	auths, closeAgent := authMethods(home, item.IdentityFile)
+	if len(auths) == 0 {
+		closeAgent()
+		return nil, nil, fmt.Errorf("no usable ssh key or agent for host %s", item.Host)
+	}
+	return &ssh.ClientConfig{
+		User:            userName,
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	}, closeAgent, nil
+}
+
+// authMethods 依次使用 IdentityFile、默认私钥和 ssh-agent 认证，返回关闭 ssh-agent 连接的函数
+func authMethods(home, identityFile string) ([]ssh.AuthMethod, func()) {
+	var signers []ssh.Signer
+	files := make([]string, 0, len(defaultIdentityFiles))
+	if identityFile != "" {
+		files = append(files, expandHome(home, identityFile))
+	} else {
+		for _, name := range defaultIdentityFiles {
+			files = append(files, filepath.Join(home, ".ssh", name))
+		}
+	}
+	for _, file := range files {
+		key, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			var passErr *ssh.PassphraseMissingError
+			if errors.As(err, &passErr) {
+				slog.Info("ssh key is encrypted, relying on ssh-agent", "file", file)
+			} else {
+				slog.Warn("failed to parse ssh key", "file", file, "error", err)
+			}
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	var auths []ssh.AuthMethod
+	if len(signers) > 0 {
+		auths = append(auths, ssh.PublicKeys(signers...))
+	}
+	closeAgent := func() {}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			auths = append(auths, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			closeAgent = func() {
+				_ = conn.Close()
+			}
+		}
+	}
+	return auths, closeAgent
+}
+
+func expandHome(home, path string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
@@ -0,0 +1,30 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/liangzhaoliang95/tview"
+)
+
+// HighlightMatches escapes text and wraps every match of re in a highlight color
+// tag. restore is the color tag used to continue the text after each match.
+func HighlightMatches(text string, re *regexp.Regexp, restore string) string {
+	if re == nil {
+		return tview.Escape(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		b.WriteString(tview.Escape(text[last:loc[0]]))
+		b.WriteString("[black:yellow]")
+		b.WriteString(tview.Escape(text[loc[0]:loc[1]]))
+		b.WriteString(restore)
+		last = loc[1]
+	}
+	b.WriteString(tview.Escape(text[last:]))
+	return b.String()
+}
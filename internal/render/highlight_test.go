@@ -0,0 +1,22 @@
+package render
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	re := regexp.MustCompile(`err\w*`)
+	got := HighlightMatches("an error and [errors]", re, "[-:-]")
+	want := "an [black:yellow]error[-:-] and [[black:yellow]errors[-:-]]"
+	if got != want {
+		t.Errorf("HighlightMatches = %q, want %q", got, want)
+	}
+	if got = HighlightMatches("[x]", nil, "[-:-]"); got != "[x[]" {
+		t.Errorf("HighlightMatches without pattern = %q", got)
+	}
+	// 空匹配不插入颜色标签
+	if got = HighlightMatches("abc", regexp.MustCompile(`z*`), "[-:-]"); got != "abc" {
+		t.Errorf("HighlightMatches with empty matches = %q", got)
+	}
+}
@@ -0,0 +1,29 @@
+package render
+
+import "strings"
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled to ceiling.
+// When ceiling is not positive the largest value is used. Only the last width
+// values are rendered.
+func Sparkline(values []float64, ceiling float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if ceiling <= 0 {
+		for _, v := range values {
+			ceiling = max(ceiling, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if ceiling > 0 && v > 0 {
+			idx = int(v/ceiling*float64(len(sparkBlocks)-1) + 0.5)
+		}
+		idx = min(max(idx, 0), len(sparkBlocks)-1)
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
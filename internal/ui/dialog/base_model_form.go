@@ -1,8 +1,11 @@
 package dialog
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/ssh_drivers"
 	"github.com/liangzhaoliang95/tview"
 )
 
@@ -25,3 +28,46 @@ func newBaseModelForm(styles *config.Dialog) *baseModelForm {
 	}
 	return b
 }
+
+// addTLSFields 添加 TLS 相关的表单项，数据库和 Redis 连接共用
+func (f *baseModelForm) addTLSFields(tlsCfg *config.TLSConfig) {
+	f.AddCheckbox("TLS:", tlsCfg.Enabled, func(checked bool) {
+		tlsCfg.Enabled = checked
+	})
+	f.AddInputField("CAFile:", tlsCfg.CAFile, 0, nil, func(v string) {
+		tlsCfg.CAFile = v
+	})
+	f.AddInputField("CertFile:", tlsCfg.CertFile, 0, nil, func(v string) {
+		tlsCfg.CertFile = v
+	})
+	f.AddInputField("KeyFile:", tlsCfg.KeyFile, 0, nil, func(v string) {
+		tlsCfg.KeyFile = v
+	})
+	f.AddCheckbox("SkipVerify:", tlsCfg.SkipVerify, func(checked bool) {
+		tlsCfg.SkipVerify = checked
+	})
+}
+
+// addSSHHostField 添加 SSH 隧道主机别名输入框，按 ~/.ssh/config 中的别名自动补全
+func (f *baseModelForm) addSSHHostField(sshHost *string) {
+	f.AddInputField("SSHHost:", *sshHost, 0, nil, func(v string) {
+		*sshHost = v
+	})
+	field, ok := f.GetFormItemByLabel("SSHHost:").(*tview.InputField)
+	if !ok {
+		return
+	}
+	aliases := ssh_drivers.HostAliases()
+	field.SetAutocompleteFunc(func(currentText string) []string {
+		if currentText == "" {
+			return nil
+		}
+		var entries []string
+		for _, alias := range aliases {
+			if strings.Contains(alias, currentText) {
+				entries = append(entries, alias)
+			}
+		}
+		return entries
+	})
+}
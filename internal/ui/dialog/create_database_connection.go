@@ -41,6 +41,15 @@ func ShowCreateCreateDatabaseConnection(
 		config.DatabaseProviderList,
 		providerMap[opts.DBConnection.Provider],
 		func(s string, i int) {
+			if opts.DBConnection.Provider != "" && opts.DBConnection.Provider != s {
+				// 切换数据库类型时，端口还是上一个类型的默认端口则一并切换
+				oldPort := config.DatabaseProviderDefaultPort[opts.DBConnection.Provider]
+				newPort, ok := config.DatabaseProviderDefaultPort[s]
+				portField, _ := f.GetFormItemByLabel("Port:").(*tview.InputField)
+				if ok && portField != nil && opts.DBConnection.Port == oldPort {
+					portField.SetText(fmt.Sprintf("%d", newPort))
+				}
+			}
 			opts.DBConnection.Provider = s
 		},
 	)
@@ -75,6 +84,11 @@ func ShowCreateCreateDatabaseConnection(
 	f.AddInputField("DBName:", "", 0, nil, func(v string) {
 		opts.DBConnection.DBName = v
 	})
+	f.AddInputField("FilePath:", opts.DBConnection.FilePath, 0, nil, func(v string) {
+		opts.DBConnection.FilePath = v
+	})
+	f.addTLSFields(&opts.DBConnection.TLS)
+	f.addSSHHostField(&opts.DBConnection.SSHHost)
 
 	f.AddButton("Test", func() {
 		// 测试数据库能否连接
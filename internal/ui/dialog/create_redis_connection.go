@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/config"
@@ -32,6 +33,16 @@ func ShowCreateRedisConnection(
 		opts.Config.Name = v
 	})
 
+	modeIndex := 0
+	for i, mode := range config.RedisModeList {
+		if mode == opts.Config.GetMode() {
+			modeIndex = i
+		}
+	}
+	f.AddDropDown("Mode:", config.RedisModeList, modeIndex, func(s string, _ int) {
+		opts.Config.Mode = s
+	})
+
 	f.AddInputField("Host:", opts.Config.Host, 0, nil, func(v string) {
 		opts.Config.Host = v
 	})
@@ -50,6 +61,15 @@ func ShowCreateRedisConnection(
 		},
 	)
 
+	f.AddInputField("Addrs:", strings.Join(opts.Config.Addrs, ","), 0, nil, func(v string) {
+		// 集群或哨兵节点，多个地址用逗号分隔
+		opts.Config.Addrs = splitAddrs(v)
+	})
+
+	f.AddInputField("MasterName:", opts.Config.MasterName, 0, nil, func(v string) {
+		opts.Config.MasterName = v
+	})
+
 	f.AddInputField("UserName:", opts.Config.UserName, 0, nil, func(v string) {
 		opts.Config.UserName = v
 	})
@@ -58,6 +78,12 @@ func ShowCreateRedisConnection(
 		opts.Config.Password = v
 	})
 
+	f.AddInputField("SentinelPassword:", opts.Config.SentinelPassword, 0, nil, func(v string) {
+		opts.Config.SentinelPassword = v
+	})
+	f.addTLSFields(&opts.Config.TLS)
+	f.addSSHHostField(&opts.Config.SSHHost)
+
 	f.AddButton("Test", func() {
 		// 测试数据库能否连接
 		opts.Test(opts.Config)
@@ -85,6 +111,9 @@ func ShowCreateRedisConnection(
 	f.SetFocus(0)
 
 	message := opts.Message
+	if message == "" {
+		message = "Addrs and MasterName are only used in cluster and sentinel mode"
+	}
 
 	modal := tview.NewModalForm("<"+opts.Title+">", f.Form)
 	modal.SetText(message)
@@ -96,3 +125,15 @@ func ShowCreateRedisConnection(
 	pages.AddPage(confirmKey, modal, false, false)
 	pages.ShowPage(confirmKey)
 }
+
+// splitAddrs 解析逗号分隔的节点地址
+func splitAddrs(v string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(v, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
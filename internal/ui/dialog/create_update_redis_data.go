@@ -17,6 +17,7 @@ type CreateUpdateRedisDataFn func(data *redis_drivers.RedisData) bool
 type CreateUpdateRedisDataOpts struct {
 	Title, Message string
 	Data           *redis_drivers.RedisData
+	TypeEditable   bool // 新建键时允许选择类型
 	Ack            CreateUpdateRedisDataFn
 	Cancel         cancelFunc
 }
@@ -31,7 +32,22 @@ func ShowCreateUpdateRedisData(
 	f.AddTextArea("Key:", opts.Data.KeyName, 0, 2, 0, func(text string) {
 		opts.Data.KeyName = text
 	})
-	f.AddTextView("Type:", opts.Data.KetType, 0, 1, true, false)
+	modal := tview.NewModalForm("<"+opts.Title+">", f.Form)
+	if opts.TypeEditable {
+		typeIndex := 0
+		for i, keyType := range redis_drivers.RedisKeyTypeList {
+			if keyType == opts.Data.KetType {
+				typeIndex = i
+			}
+		}
+		f.AddDropDown("Type:", redis_drivers.RedisKeyTypeList, typeIndex, func(s string, _ int) {
+			opts.Data.KetType = s
+			// 切换类型时提示值的填写格式
+			modal.SetText(redisValueHint(s, opts.Message))
+		})
+	} else {
+		f.AddTextView("Type:", opts.Data.KetType, 0, 1, true, false)
+	}
 
 	f.AddInputField(
 		"TTL:",
@@ -73,10 +89,7 @@ func ShowCreateUpdateRedisData(
 	}
 	f.SetFocus(0)
 
-	message := opts.Message
-
-	modal := tview.NewModalForm("<"+opts.Title+">", f.Form)
-	modal.SetText(message)
+	modal.SetText(redisValueHint(opts.Data.KetType, opts.Message))
 	modal.SetTextColor(styles.FgColor.Color())
 	modal.SetDoneFunc(func(int, string) {
 		dismissConfirm(pages)
@@ -85,3 +98,19 @@ func ShowCreateUpdateRedisData(
 	pages.AddPage(confirmKey, modal, false, false)
 	pages.ShowPage(confirmKey)
 }
+
+// redisValueHint 不同类型键的值填写格式提示
+func redisValueHint(keyType, message string) string {
+	switch keyType {
+	case redis_drivers.RedisTypeHash:
+		return "Value: one field=value per line"
+	case redis_drivers.RedisTypeList, redis_drivers.RedisTypeSet:
+		return "Value: one element per line"
+	case redis_drivers.RedisTypeZSet:
+		return "Value: one member=score per line"
+	case redis_drivers.RedisTypeStream:
+		return "Value: one field=value per line, added as a single message"
+	default:
+		return message
+	}
+}
@@ -0,0 +1,91 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+const (
+	dbValueLines      = 5
+	dbPreviewMaxLines = 15
+)
+
+type DatabaseEditCellFn func(value string, null bool) bool
+
+type DatabaseEditCellOpts struct {
+	Column string // 编辑的列名
+	Value  string // 当前的值
+	Null   bool   // 当前是否为 NULL
+	Ack    DatabaseEditCellFn
+	Cancel cancelFunc
+}
+
+// ShowDatabaseEditCell 编辑单元格的对话框，勾选 NULL 时忽略输入的值
+func ShowDatabaseEditCell(styles *config.Dialog, pages *ui.Pages, opts *DatabaseEditCellOpts) {
+	f := newBaseModelForm(styles)
+	f.SetItemPadding(0)
+	value, null := opts.Value, opts.Null
+	f.AddTextArea("Value:", value, 0, dbValueLines, 0, func(v string) {
+		value = v
+	})
+	f.AddCheckbox("NULL:", null, func(checked bool) {
+		null = checked
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(value, null) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Edit "+opts.Column, "", opts.Cancel)
+}
+
+type DatabaseChangesOpts struct {
+	Statements []string // 将要执行的语句
+	Ack        func()
+	Cancel     cancelFunc
+}
+
+// ShowDatabaseChanges 提交前预览将在一个事务中执行的语句
+func ShowDatabaseChanges(styles *config.Dialog, pages *ui.Pages, opts *DatabaseChangesOpts) {
+	f := newBaseModelForm(styles)
+	f.SetItemPadding(0)
+	text := strings.Join(opts.Statements, ";\n") + ";"
+	preview := tview.NewTextArea().
+		SetText(text, false).
+		SetSize(min(strings.Count(text, "\n")+1, dbPreviewMaxLines), 0)
+	// 只读，只保留滚动相关的按键
+	preview.SetInputCapture(func(evt *tcell.EventKey) *tcell.EventKey {
+		switch evt.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight,
+			tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyHome, tcell.KeyEnd,
+			tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape:
+			return evt
+		}
+		return nil
+	})
+	f.AddFormItem(preview)
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("Commit", func() {
+		dismissConfirm(pages)
+		opts.Ack()
+	})
+	showDockerForm(styles, pages, f, "Commit Changes",
+		fmt.Sprintf("%d statements will be executed in one transaction", len(opts.Statements)),
+		opts.Cancel)
+}
@@ -0,0 +1,81 @@
+package dialog
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+type DatabaseExportArgs struct {
+	Path      string // 导出的文件路径
+	Format    string // database_drivers.ExportFormatList 中的一种
+	Full      bool   // 导出完整的结果，否则只导出当前展示的数据
+	Table     string // SQL INSERT 使用的表名
+	Overwrite bool   // 文件已存在时是否覆盖
+}
+
+type DatabaseExportFn func(args DatabaseExportArgs) bool
+
+type DatabaseExportOpts struct {
+	Message string
+	Scopes  []string // 导出范围的选项，依次为当前数据和完整结果
+	Args    DatabaseExportArgs
+	Ack     DatabaseExportFn
+	Cancel  cancelFunc
+}
+
+// ShowDatabaseExport 选择导出格式、范围和目标文件，切换格式时同步修改文件扩展名
+func ShowDatabaseExport(styles *config.Dialog, pages *ui.Pages, opts *DatabaseExportOpts) {
+	f := newBaseModelForm(styles)
+	args := opts.Args
+	if args.Format == "" {
+		args.Format = database_drivers.ExportFormatList[0]
+	}
+	f.AddInputField("File:", args.Path, 0, nil, func(v string) {
+		args.Path = strings.TrimSpace(v)
+	})
+	pathField, _ := f.GetFormItem(0).(*tview.InputField)
+	f.AddDropDown("Format:", database_drivers.ExportFormatList,
+		max(slices.Index(database_drivers.ExportFormatList, args.Format), 0),
+		func(s string, _ int) {
+			prev := args.Format
+			args.Format = s
+			if pathField == nil || prev == s {
+				return
+			}
+			// 只替换由格式决定的扩展名，保留用户自定义的扩展名
+			ext := filepath.Ext(args.Path)
+			if ext == database_drivers.ExportExt(prev) {
+				pathField.SetText(strings.TrimSuffix(args.Path, ext) + database_drivers.ExportExt(s))
+			}
+		})
+	scope := 0
+	if args.Full {
+		scope = 1
+	}
+	f.AddDropDown("Scope:", opts.Scopes, scope, func(_ string, i int) {
+		args.Full = i == 1
+	})
+	f.AddInputField("Table:", args.Table, 0, nil, func(v string) {
+		args.Table = strings.TrimSpace(v)
+	})
+	f.AddCheckbox("Overwrite:", args.Overwrite, func(checked bool) {
+		args.Overwrite = checked
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("Export", func() {
+		closeThenAck(pages, func() bool {
+			return opts.Ack(args)
+		}, opts.Cancel)
+	})
+	showDockerForm(styles, pages, f, "Export", opts.Message, opts.Cancel)
+}
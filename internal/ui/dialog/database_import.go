@@ -0,0 +1,150 @@
+package dialog
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+type DatabaseImportArgs struct {
+	Path   string // 导入的文件路径
+	Format string // database_drivers.ImportFormatList 中的一种
+	Table  string // 目标表
+}
+
+type DatabaseImportFn func(args DatabaseImportArgs) bool
+
+type DatabaseImportOpts struct {
+	Tables []string // 可选的目标表
+	Args   DatabaseImportArgs
+	Browse func(args DatabaseImportArgs) // 从文件浏览器中选择文件
+	Ack    DatabaseImportFn
+	Cancel cancelFunc
+}
+
+// ShowDatabaseImport 导入向导的第一步，选择文件和目标表，输入路径时按扩展名切换格式
+func ShowDatabaseImport(styles *config.Dialog, pages *ui.Pages, opts *DatabaseImportOpts) {
+	f := newBaseModelForm(styles)
+	args := opts.Args
+	if args.Format == "" {
+		args.Format = database_drivers.ImportFormatOf(args.Path)
+	}
+	var formatField *tview.DropDown
+	f.AddInputField("File:", args.Path, 0, nil, func(v string) {
+		args.Path = strings.TrimSpace(v)
+		if formatField != nil {
+			formatField.SetCurrentOption(slices.Index(database_drivers.ImportFormatList, database_drivers.ImportFormatOf(args.Path)))
+		}
+	})
+	f.AddDropDown("Format:", database_drivers.ImportFormatList,
+		max(slices.Index(database_drivers.ImportFormatList, args.Format), 0),
+		func(s string, _ int) {
+			args.Format = s
+		})
+	formatField, _ = f.GetFormItem(1).(*tview.DropDown)
+	f.AddDropDown("Table:", opts.Tables, max(slices.Index(opts.Tables, args.Table), 0), func(s string, _ int) {
+		args.Table = s
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("Browse", func() {
+		dismissConfirm(pages)
+		opts.Browse(args)
+	})
+	f.AddButton("Next", func() {
+		closeThenAck(pages, func() bool {
+			return opts.Ack(args)
+		}, opts.Cancel)
+	})
+	showDockerForm(styles, pages, f, "Import", "CSV with a header row, JSON array or JSON Lines", opts.Cancel)
+}
+
+type DatabaseImportColumnFn func(source int) bool
+
+type DatabaseImportColumnOpts struct {
+	Column  string   // 目标列
+	Sources []string // 文件中的列
+	Source  int      // 当前映射的列，-1 表示不导入
+	Ack     DatabaseImportColumnFn
+	Cancel  cancelFunc
+}
+
+// ShowDatabaseImportColumn 选择目标列对应的文件中的列，不导入时使用列的默认值
+func ShowDatabaseImportColumn(styles *config.Dialog, pages *ui.Pages, opts *DatabaseImportColumnOpts) {
+	f := newBaseModelForm(styles)
+	source := opts.Source
+	options := append([]string{"(default)"}, opts.Sources...)
+	f.AddDropDown("Source:", options, source+1, func(_ string, i int) {
+		source = i - 1
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(source) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Map "+opts.Column, "", opts.Cancel)
+}
+
+type DatabaseImportOptionsFn func(batchSize int, onDuplicate string, emptyAsNull, skipMalformed bool) bool
+
+type DatabaseImportOptionsOpts struct {
+	BatchSize     int
+	OnDuplicate   string // database_drivers.ImportDuplicateList 中的一种
+	EmptyAsNull   bool
+	SkipMalformed bool
+	Ack           DatabaseImportOptionsFn
+	Cancel        cancelFunc
+}
+
+// ShowDatabaseImportOptions 设置每批写入的行数、重复时的处理方式、空串的处理和是否跳过格式错误的行
+func ShowDatabaseImportOptions(styles *config.Dialog, pages *ui.Pages, opts *DatabaseImportOptionsOpts) {
+	f := newBaseModelForm(styles)
+	batchSize := strconv.Itoa(opts.BatchSize)
+	onDuplicate, emptyAsNull, skipMalformed := opts.OnDuplicate, opts.EmptyAsNull, opts.SkipMalformed
+	f.AddInputField("Batch Size:", batchSize, 0, tview.InputFieldInteger, func(v string) {
+		batchSize = strings.TrimSpace(v)
+	})
+	f.AddDropDown("On Duplicate:", database_drivers.ImportDuplicateList,
+		max(slices.Index(database_drivers.ImportDuplicateList, onDuplicate), 0),
+		func(s string, _ int) {
+			onDuplicate = s
+		})
+	f.AddCheckbox("Empty As NULL:", emptyAsNull, func(checked bool) {
+		emptyAsNull = checked
+	})
+	f.AddCheckbox("Skip Malformed:", skipMalformed, func(checked bool) {
+		skipMalformed = checked
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		n, err := strconv.Atoi(batchSize)
+		if err != nil {
+			n = 0
+		}
+		if !opts.Ack(n, onDuplicate, emptyAsNull, skipMalformed) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Import Options", "Skip: keep existing rows  Update: ON DUPLICATE KEY UPDATE", opts.Cancel)
+}
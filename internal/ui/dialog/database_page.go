@@ -0,0 +1,46 @@
+package dialog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+type DatabaseJumpPageFn func(page int) bool
+
+type DatabaseJumpPageOpts struct {
+	Page      int // 当前页，从 1 开始
+	PageCount int // 总页数
+	Ack       DatabaseJumpPageFn
+	Cancel    cancelFunc
+}
+
+// ShowDatabaseJumpPage 跳转到指定页的对话框
+func ShowDatabaseJumpPage(styles *config.Dialog, pages *ui.Pages, opts *DatabaseJumpPageOpts) {
+	f := newBaseModelForm(styles)
+	page := strconv.Itoa(opts.Page)
+	f.AddInputField("Page:", page, 0, tview.InputFieldInteger, func(v string) {
+		page = strings.TrimSpace(v)
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		n, err := strconv.Atoi(page)
+		if err != nil {
+			n = 0
+		}
+		if !opts.Ack(n) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Go To Page", fmt.Sprintf("1 - %d", opts.PageCount), opts.Cancel)
+}
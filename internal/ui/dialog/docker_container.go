@@ -0,0 +1,66 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+)
+
+type KillDockerContainerFn func(signal string)
+
+type KillDockerContainerOpts struct {
+	Message string // 要结束的容器
+	Ack     KillDockerContainerFn
+	Cancel  cancelFunc
+}
+
+// ShowKillDockerContainer 选择信号并确认结束容器
+func ShowKillDockerContainer(styles *config.Dialog, pages *ui.Pages, opts *KillDockerContainerOpts) {
+	f := newBaseModelForm(styles)
+	signal := docker_drivers.DockerKillSignalList[0]
+	f.AddDropDown("Signal:", docker_drivers.DockerKillSignalList, 0, func(s string, i int) {
+		signal = s
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		dismissConfirm(pages)
+		opts.Ack(signal)
+	})
+	showDockerForm(styles, pages, f, "Kill Container", opts.Message, opts.Cancel)
+}
+
+type RenameDockerContainerFn func(name string) bool
+
+type RenameDockerContainerOpts struct {
+	Name   string
+	Ack    RenameDockerContainerFn
+	Cancel cancelFunc
+}
+
+// ShowRenameDockerContainer 重命名容器对话框
+func ShowRenameDockerContainer(styles *config.Dialog, pages *ui.Pages, opts *RenameDockerContainerOpts) {
+	f := newBaseModelForm(styles)
+	name := opts.Name
+	f.AddInputField("Name:", name, 0, nil, func(v string) {
+		name = strings.TrimSpace(v)
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(name) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Rename Container", opts.Name, opts.Cancel)
+}
@@ -0,0 +1,49 @@
+package dialog
+
+import (
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// DockerHostItem 引擎列表中的一项
+type DockerHostItem struct {
+	Name    string
+	Address string
+	Current bool
+}
+
+type SwitchDockerHostFn func(index int)
+
+type SwitchDockerHostOpts struct {
+	Hosts  []DockerHostItem
+	Ack    SwitchDockerHostFn
+	Cancel cancelFunc
+}
+
+// ShowDockerHosts 选择要切换的 Docker 引擎，当前引擎以 * 标记
+func ShowDockerHosts(styles *config.Dialog, pages *ui.Pages, opts *SwitchDockerHostOpts) {
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetMainTextColor(styles.FgColor.Color())
+	current := 0
+	for i, h := range opts.Hosts {
+		mark := "  "
+		if h.Current {
+			mark, current = "* ", i
+		}
+		list.AddItem(mark+h.Name+"  "+h.Address, "", 0, nil)
+	}
+	list.SetCurrentItem(current)
+
+	modal := ui.NewModalList("<Switch Docker Host>", list)
+	modal.SetDoneFunc(func(index int, _ string) {
+		dismissConfirm(pages)
+		if index < 0 {
+			opts.Cancel()
+			return
+		}
+		opts.Ack(index)
+	})
+	pages.AddPage(confirmKey, modal, false, false)
+	pages.ShowPage(confirmKey)
+}
@@ -0,0 +1,38 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+)
+
+type DockerImagePullFn func(ref string)
+
+type DockerImagePullOpts struct {
+	Reference string
+	Ack       DockerImagePullFn
+	Cancel    cancelFunc
+}
+
+// ShowDockerImagePull 输入镜像引用后拉取镜像，Ack 在对话框关闭后调用，便于展示拉取进度
+func ShowDockerImagePull(styles *config.Dialog, pages *ui.Pages, opts *DockerImagePullOpts) {
+	f := newBaseModelForm(styles)
+	ref := opts.Reference
+	f.AddInputField("Image:", ref, 0, nil, func(v string) {
+		ref = strings.TrimSpace(v)
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("Pull", func() {
+		if ref == "" {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Ack(ref)
+	})
+	showDockerForm(styles, pages, f, "Pull Image", "e.g. nginx:latest, registry.local:5000/app:v1", opts.Cancel)
+}
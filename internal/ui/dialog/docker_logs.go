@@ -0,0 +1,43 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+)
+
+type DockerLogOptionsFn func(tail, since string) bool
+
+type DockerLogOptionsOpts struct {
+	Tail   string
+	Since  string
+	Ack    DockerLogOptionsFn
+	Cancel cancelFunc
+}
+
+// ShowDockerLogOptions 修改日志读取的行数和时间范围
+func ShowDockerLogOptions(styles *config.Dialog, pages *ui.Pages, opts *DockerLogOptionsOpts) {
+	f := newBaseModelForm(styles)
+	tail, since := opts.Tail, opts.Since
+	f.AddInputField("Tail:", tail, 0, nil, func(v string) {
+		tail = strings.TrimSpace(v)
+	})
+	f.AddInputField("Since:", since, 0, nil, func(v string) {
+		since = strings.TrimSpace(v)
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(tail, since) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Log Options",
+		"Tail: number of lines, empty for all\nSince: duration like 30s, 5m, 1h, empty for no limit", opts.Cancel)
+}
@@ -0,0 +1,119 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+type CreateDockerNetworkFn func(name, driver string, internal bool) bool
+
+type CreateDockerNetworkOpts struct {
+	Ack    CreateDockerNetworkFn
+	Cancel cancelFunc
+}
+
+// ShowCreateDockerNetwork 创建网络对话框
+func ShowCreateDockerNetwork(styles *config.Dialog, pages *ui.Pages, opts *CreateDockerNetworkOpts) {
+	f := newBaseModelForm(styles)
+	var (
+		name     string
+		driver   = docker_drivers.DockerNetworkDriverList[0]
+		internal bool
+	)
+	f.AddInputField("Name:", "", 0, nil, func(v string) {
+		name = strings.TrimSpace(v)
+	})
+	f.AddDropDown("Driver:", docker_drivers.DockerNetworkDriverList, 0, func(s string, i int) {
+		driver = s
+	})
+	f.AddCheckbox("Internal:", false, func(checked bool) {
+		internal = checked
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(name, driver, internal) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Create Network", "", opts.Cancel)
+}
+
+type DockerNetworkContainerFn func(container string, force bool) bool
+
+type DockerNetworkContainerOpts struct {
+	Title      string
+	Message    string
+	Containers []string // 可选的容器名称
+	Force      bool     // 是否展示 Force 选项，断开连接时使用
+	Ack        DockerNetworkContainerFn
+	Cancel     cancelFunc
+}
+
+// ShowDockerNetworkContainer 选择容器接入或断开网络
+func ShowDockerNetworkContainer(styles *config.Dialog, pages *ui.Pages, opts *DockerNetworkContainerOpts) {
+	f := newBaseModelForm(styles)
+	selected := ""
+	if len(opts.Containers) > 0 {
+		selected = opts.Containers[0]
+	}
+	f.AddDropDown("Container:", opts.Containers, 0, func(s string, i int) {
+		selected = s
+	})
+	if opts.Force {
+		f.AddCheckbox("Force:", false, func(checked bool) {
+			f.force = checked
+		})
+	}
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if selected == "" || !opts.Ack(selected, f.force) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, opts.Title, opts.Message, opts.Cancel)
+}
+
+// showDockerForm 设置按钮样式并以模态框展示 Docker 资源表单
+func showDockerForm(
+	styles *config.Dialog,
+	pages *ui.Pages,
+	f *baseModelForm,
+	title, message string,
+	cancel cancelFunc,
+) {
+	for i := range f.GetButtonCount() {
+		b := f.GetButton(i)
+		if b == nil {
+			continue
+		}
+		b.SetBackgroundColor(tcell.ColorYellow)
+	}
+	f.SetFocus(0)
+
+	modal := tview.NewModalForm("<"+title+">", f.Form)
+	modal.SetText(message)
+	modal.SetTextColor(styles.FgColor.Color())
+	modal.SetDoneFunc(func(int, string) {
+		dismissConfirm(pages)
+		cancel()
+	})
+	pages.AddPage(confirmKey, modal, false, false)
+	pages.ShowPage(confirmKey)
+}
@@ -0,0 +1,127 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+const (
+	runImageLabel    = "Image:"
+	runNameLabel     = "Name:"
+	runCommandLabel  = "Command:"
+	runEnvLabel      = "Env:"
+	runPortsLabel    = "Ports:"
+	runVolumesLabel  = "Volumes:"
+	runNetworkLabel  = "Network:"
+	runRestartLabel  = "Restart:"
+	runAutoRmLabel   = "Auto Remove:"
+	runTemplateLabel = "Template:"
+	runTextAreaLines = 3
+)
+
+type DockerRunFn func(t *config.DockerRunTemplate) bool
+
+type DockerRunOpts struct {
+	Image     string                      // 默认镜像，从镜像列表打开时为选中的镜像
+	Networks  []string                    // 可选的网络，第一个为默认网络
+	Templates []*config.DockerRunTemplate // 最近使用的运行参数
+	Ack       DockerRunFn
+	Cancel    cancelFunc
+}
+
+// ShowDockerRun 运行容器对话框，Env、Ports、Volumes 每行一个
+func ShowDockerRun(styles *config.Dialog, pages *ui.Pages, opts *DockerRunOpts) {
+	f := newBaseModelForm(styles)
+	f.SetItemPadding(0)
+
+	if len(opts.Templates) > 0 {
+		titles := []string{"<none>"}
+		for _, t := range opts.Templates {
+			titles = append(titles, t.Title())
+		}
+		f.AddDropDown(runTemplateLabel, titles, 0, func(_ string, i int) {
+			if i > 0 {
+				fillDockerRunForm(f, opts.Networks, opts.Templates[i-1])
+			}
+		})
+	}
+	f.AddInputField(runImageLabel, opts.Image, 0, nil, nil)
+	f.AddInputField(runNameLabel, "", 0, nil, nil)
+	f.AddInputField(runCommandLabel, "", 0, nil, nil)
+	f.AddTextArea(runEnvLabel, "", 0, runTextAreaLines, 0, nil)
+	f.AddTextArea(runPortsLabel, "", 0, runTextAreaLines, 0, nil)
+	f.AddTextArea(runVolumesLabel, "", 0, runTextAreaLines, 0, nil)
+	f.AddDropDown(runNetworkLabel, opts.Networks, 0, nil)
+	f.AddDropDown(runRestartLabel, docker_drivers.DockerRestartPolicyList, 0, nil)
+	f.AddCheckbox(runAutoRmLabel, false, nil)
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		closeThenAck(pages, func() bool {
+			return opts.Ack(readDockerRunForm(f))
+		}, opts.Cancel)
+	})
+	showDockerForm(styles, pages, f, "Run Container",
+		"Env, Ports and Volumes take one entry per line\nPorts: [ip:][hostPort:]containerPort[/udp]  Volumes: source:/path[:ro]",
+		opts.Cancel)
+}
+
+// fillDockerRunForm 用模板填充表单
+func fillDockerRunForm(f *baseModelForm, networks []string, t *config.DockerRunTemplate) {
+	f.GetFormItemByLabel(runImageLabel).(*tview.InputField).SetText(t.Image)
+	f.GetFormItemByLabel(runNameLabel).(*tview.InputField).SetText(t.Name)
+	f.GetFormItemByLabel(runCommandLabel).(*tview.InputField).SetText(t.Command)
+	f.GetFormItemByLabel(runEnvLabel).(*tview.TextArea).SetText(strings.Join(t.Env, "\n"), false)
+	f.GetFormItemByLabel(runPortsLabel).(*tview.TextArea).SetText(strings.Join(t.Ports, "\n"), false)
+	f.GetFormItemByLabel(runVolumesLabel).(*tview.TextArea).SetText(strings.Join(t.Volumes, "\n"), false)
+	selectOption(f.GetFormItemByLabel(runNetworkLabel).(*tview.DropDown), networks, t.Network)
+	selectOption(f.GetFormItemByLabel(runRestartLabel).(*tview.DropDown), docker_drivers.DockerRestartPolicyList, t.RestartPolicy)
+	f.GetFormItemByLabel(runAutoRmLabel).(*tview.Checkbox).SetChecked(t.AutoRemove)
+}
+
+func selectOption(d *tview.DropDown, options []string, value string) {
+	for i, option := range options {
+		if option == value {
+			d.SetCurrentOption(i)
+			return
+		}
+	}
+}
+
+// readDockerRunForm 读取表单中的运行参数
+func readDockerRunForm(f *baseModelForm) *config.DockerRunTemplate {
+	text := func(label string) string {
+		return strings.TrimSpace(f.GetFormItemByLabel(label).(*tview.InputField).GetText())
+	}
+	lines := func(label string) []string {
+		var list []string
+		for _, line := range strings.Split(f.GetFormItemByLabel(label).(*tview.TextArea).GetText(), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				list = append(list, line)
+			}
+		}
+		return list
+	}
+	option := func(label string) string {
+		_, value := f.GetFormItemByLabel(label).(*tview.DropDown).GetCurrentOption()
+		return value
+	}
+	return &config.DockerRunTemplate{
+		Image:         text(runImageLabel),
+		Name:          text(runNameLabel),
+		Command:       text(runCommandLabel),
+		Env:           lines(runEnvLabel),
+		Ports:         lines(runPortsLabel),
+		Volumes:       lines(runVolumesLabel),
+		Network:       option(runNetworkLabel),
+		RestartPolicy: option(runRestartLabel),
+		AutoRemove:    f.GetFormItemByLabel(runAutoRmLabel).(*tview.Checkbox).IsChecked(),
+	}
+}
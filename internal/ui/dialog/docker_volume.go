@@ -0,0 +1,43 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+)
+
+type CreateDockerVolumeFn func(name, driver string) bool
+
+type CreateDockerVolumeOpts struct {
+	Ack    CreateDockerVolumeFn
+	Cancel cancelFunc
+}
+
+// ShowCreateDockerVolume 创建数据卷对话框，名称留空时由 Docker 生成
+func ShowCreateDockerVolume(styles *config.Dialog, pages *ui.Pages, opts *CreateDockerVolumeOpts) {
+	f := newBaseModelForm(styles)
+	var (
+		name   string
+		driver = "local"
+	)
+	f.AddInputField("Name:", "", 0, nil, func(v string) {
+		name = strings.TrimSpace(v)
+	})
+	f.AddInputField("Driver:", driver, 0, nil, func(v string) {
+		driver = strings.TrimSpace(v)
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("OK", func() {
+		if !opts.Ack(name, driver) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	showDockerForm(styles, pages, f, "Create Volume", "Leave name empty to generate one", opts.Cancel)
+}
@@ -25,6 +25,12 @@ func (_this *LoadingDialog) Hide() {
 	_this.forceDrawFn()
 }
 
+// SetMessage 更新加载提示，用于展示耗时操作的进度，需在 UI 线程中调用
+func (_this *LoadingDialog) SetMessage(message string) {
+	_this.Message = message
+	_this.modalLoading.SetText(message)
+}
+
 func ShowLoadingDialog(pages *ui.Pages, Message string, forceDrawFn ForceDrawFn) *LoadingDialog {
 
 	l := &LoadingDialog{
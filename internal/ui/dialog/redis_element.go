@@ -0,0 +1,108 @@
+package dialog
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/redis_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// RedisElement 集合类型键中的一个元素
+type RedisElement struct {
+	Field string // hash 字段名、list 下标、zset 分数、stream 消息 ID
+	Value string // hash 字段值、list 元素、set/zset 成员、stream 消息字段
+	Head  bool   // list 是否插入到头部
+}
+
+type RedisElementFn func(element *RedisElement) bool
+
+type RedisElementOpts struct {
+	Title   string
+	KeyType string
+	Edit    bool // 编辑已有元素，不可修改的部分只读展示
+	Element *RedisElement
+	Ack     RedisElementFn
+	Cancel  cancelFunc
+}
+
+func ShowRedisElement(styles *config.Dialog, pages *ui.Pages, opts *RedisElementOpts) {
+	f := newBaseModelForm(styles)
+	e := opts.Element
+	message := ""
+
+	switch opts.KeyType {
+	case redis_drivers.RedisTypeHash:
+		f.AddInputField("Field:", e.Field, 0, nil, func(v string) {
+			e.Field = v
+		})
+		f.AddTextArea("Value:", e.Value, 0, 3, 0, func(v string) {
+			e.Value = v
+		})
+	case redis_drivers.RedisTypeList:
+		if opts.Edit {
+			f.AddTextView("Index:", e.Field, 0, 1, true, false)
+		} else {
+			f.AddDropDown("Position:", []string{"Tail", "Head"}, 0, func(s string, i int) {
+				e.Head = i == 1
+			})
+		}
+		f.AddTextArea("Value:", e.Value, 0, 3, 0, func(v string) {
+			e.Value = v
+		})
+	case redis_drivers.RedisTypeSet:
+		f.AddTextArea("Member:", e.Value, 0, 3, 0, func(v string) {
+			e.Value = v
+		})
+	case redis_drivers.RedisTypeZSet:
+		if opts.Edit {
+			f.AddTextView("Member:", e.Value, 0, 1, true, false)
+		} else {
+			f.AddInputField("Member:", e.Value, 0, nil, func(v string) {
+				e.Value = v
+			})
+		}
+		f.AddInputField("Score:", e.Field, 0, nil, func(v string) {
+			e.Field = v
+		})
+	case redis_drivers.RedisTypeStream:
+		message = "Fields: one field=value per line"
+		f.AddInputField("ID:", e.Field, 0, nil, func(v string) {
+			e.Field = v
+		})
+		f.AddTextArea("Fields:", e.Value, 0, 3, 0, func(v string) {
+			e.Value = v
+		})
+	}
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+
+	f.AddButton("OK", func() {
+		if !opts.Ack(e) {
+			return
+		}
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	for i := range 2 {
+		b := f.GetButton(i)
+		if b == nil {
+			continue
+		}
+		b.SetBackgroundColor(tcell.ColorYellow)
+	}
+	f.SetFocus(0)
+
+	modal := tview.NewModalForm("<"+opts.Title+">", f.Form)
+	modal.SetText(message)
+	modal.SetTextColor(styles.FgColor.Color())
+	modal.SetDoneFunc(func(int, string) {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	pages.AddPage(confirmKey, modal, false, false)
+	pages.ShowPage(confirmKey)
+}
@@ -0,0 +1,72 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/20 11:20
+ */
+
+package dialog
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+type RedisPatternDeleteOpts struct {
+	Pattern string
+	// Preview 预览匹配结果，返回展示的信息以及是否有可删除的键
+	Preview func(pattern string) (string, bool)
+	// Ack 确认删除，对话框关闭后调用
+	Ack    func(pattern string)
+	Cancel cancelFunc
+}
+
+// ShowRedisPatternDelete 按匹配模式批量删除键，必须先预览再删除
+func ShowRedisPatternDelete(styles *config.Dialog, pages *ui.Pages, opts *RedisPatternDeleteOpts) {
+	f := newBaseModelForm(styles)
+	modal := tview.NewModalForm("<Delete By Pattern>", f.Form)
+
+	pattern := opts.Pattern
+	previewed := ""
+	f.AddInputField("Pattern:", pattern, 0, nil, func(v string) {
+		pattern = v
+	})
+
+	f.AddButton("Cancel", func() {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	f.AddButton("Preview", func() {
+		message, ok := opts.Preview(pattern)
+		modal.SetText(message)
+		previewed = ""
+		if ok {
+			previewed = pattern
+		}
+	})
+	f.AddButton("Delete", func() {
+		if previewed == "" || previewed != pattern {
+			modal.SetText("Preview the pattern before deleting.")
+			return
+		}
+		dismissConfirm(pages)
+		opts.Ack(pattern)
+	})
+	for i := range 3 {
+		b := f.GetButton(i)
+		if b == nil {
+			continue
+		}
+		b.SetBackgroundColor(tcell.ColorYellow)
+	}
+	f.SetFocus(0)
+
+	modal.SetText("Preview the matched keys, then delete them in batches.")
+	modal.SetTextColor(styles.FgColor.Color())
+	modal.SetDoneFunc(func(int, string) {
+		dismissConfirm(pages)
+		opts.Cancel()
+	})
+	pages.AddPage(confirmKey, modal, false, false)
+	pages.ShowPage(confirmKey)
+}
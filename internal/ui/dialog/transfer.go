@@ -89,11 +89,9 @@ func ShowUploads(styles *config.Dialog, pages *ui.Pages, opts *TransferDialogOpt
 	})
 
 	f.AddButton("OK", func() {
-		if !opts.Ack(args) {
-			return
-		}
-		dismissConfirm(pages)
-		opts.Cancel()
+		closeThenAck(pages, func() bool {
+			return opts.Ack(args)
+		}, opts.Cancel)
 	})
 	for i := range 2 {
 		b := f.GetButton(i)
@@ -122,3 +120,14 @@ func ShowUploads(styles *config.Dialog, pages *ui.Pages, opts *TransferDialogOpt
 func dismissConfirm(pages *ui.Pages) {
 	pages.RemovePage(confirmKey)
 }
+
+// closeThenAck 先关闭对话框并调用 cancel 恢复焦点，再调用 ack，ack 中可以直接展示进度对话框或打开新页面
+// ack 返回 false 时重新展示对话框，已填写的内容保留
+func closeThenAck(pages *ui.Pages, ack func() bool, cancel cancelFunc) {
+	modal := pages.GetPrimitive(confirmKey)
+	dismissConfirm(pages)
+	cancel()
+	if !ack() && modal != nil {
+		pages.AddPage(confirmKey, modal, false, true)
+	}
+}
@@ -130,6 +130,10 @@ func (_this *DatabaseBrowser) _refreshTableData() {
 		_this._initConfigTableHeader()
 		// 设置数据
 		for i, connection := range _this.config.DBConnections {
+			host := connection.Host
+			if connection.IsFileBased() {
+				host = connection.FilePath
+			}
 			_this.connList.SetCell(
 				i+1,
 				0,
@@ -149,7 +153,7 @@ func (_this *DatabaseBrowser) _refreshTableData() {
 			_this.connList.SetCell(
 				i+1,
 				2,
-				tview.NewTableCell(connection.Host).
+				tview.NewTableCell(host).
 					SetTextColor(tcell.ColorWhite).
 					SetAlign(tview.AlignLeft).
 					SetExpansion(1),
@@ -235,20 +239,7 @@ func (_this *DatabaseBrowser) createDatabaseConnectionModel(evt *tcell.EventKey)
 						Warn("Connection already exists. Please choose a different name.")
 					return false
 				}
-				if opts.Host == "" {
-					_this.app.UI.Flash().Warn("Host cannot be empty.")
-					return false
-				}
-				if opts.Port <= 0 {
-					_this.app.UI.Flash().Warn("Port must be a positive integer.")
-					return false
-				}
-				if opts.UserName == "" {
-					_this.app.UI.Flash().Warn("Username cannot be empty.")
-					return false
-				}
-				if opts.Password == "" {
-					_this.app.UI.Flash().Warn("Password cannot be empty.")
+				if !_this._checkConnection(opts) {
 					return false
 				}
 
@@ -299,20 +290,7 @@ func (_this *DatabaseBrowser) createDatabaseConnectionModel(evt *tcell.EventKey)
 					}
 				}
 
-				if newConfig.Host == "" {
-					_this.app.UI.Flash().Warn("Host cannot be empty.")
-					return false
-				}
-				if newConfig.Port <= 0 {
-					_this.app.UI.Flash().Warn("Port must be a positive integer.")
-					return false
-				}
-				if newConfig.UserName == "" {
-					_this.app.UI.Flash().Warn("Username cannot be empty.")
-					return false
-				}
-				if newConfig.Password == "" {
-					_this.app.UI.Flash().Warn("Password cannot be empty.")
+				if !_this._checkConnection(newConfig) {
 					return false
 				}
 
@@ -320,6 +298,8 @@ func (_this *DatabaseBrowser) createDatabaseConnectionModel(evt *tcell.EventKey)
 					_this.app.UI.Flash().Warn("Failed to save configuration: " + err.Error())
 					return false
 				}
+				// 配置变更后丢弃旧的连接
+				database_drivers.ResetConnect(newConfig)
 				_this._refreshTableData()
 				return true
 			},
@@ -378,6 +358,34 @@ func (_this *DatabaseBrowser) deleteDatabaseConnectionModel(evt *tcell.EventKey)
 	return nil
 }
 
+// _checkConnection 校验连接配置，文件型数据库只需要文件路径
+func (_this *DatabaseBrowser) _checkConnection(conn *config.DBConnection) bool {
+	if conn.IsFileBased() {
+		if conn.FilePath == "" {
+			_this.app.UI.Flash().Warn("File path cannot be empty.")
+			return false
+		}
+		return true
+	}
+	if conn.Host == "" {
+		_this.app.UI.Flash().Warn("Host cannot be empty.")
+		return false
+	}
+	if conn.Port <= 0 {
+		_this.app.UI.Flash().Warn("Port must be a positive integer.")
+		return false
+	}
+	if conn.UserName == "" {
+		_this.app.UI.Flash().Warn("Username cannot be empty.")
+		return false
+	}
+	if conn.Password == "" {
+		_this.app.UI.Flash().Warn("Password cannot be empty.")
+		return false
+	}
+	return true
+}
+
 func (_this *DatabaseBrowser) _getCurrentSelectKey() {
 	row, _ := _this.connList.GetSelection()
 	currentSelectedName := _this.connList.GetCell(row, 0).Text
@@ -422,6 +430,13 @@ func loadConfiguration() (*config.DatabaseConfig, error) {
 	return databaseCfg, errs
 }
 
+// readConfiguration 只读取配置，不回写配置文件
+func readConfiguration() (*config.DatabaseConfig, error) {
+	databaseCfg := config.NewDatabaseConfig()
+	err := databaseCfg.Load(config.AppDatabaseConfigFile, false)
+	return databaseCfg, err
+}
+
 func NewDatabaseBrowser(app *App) *DatabaseBrowser {
 	databaseConfig, err := loadConfiguration()
 	if err != nil {
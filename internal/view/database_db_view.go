@@ -36,6 +36,21 @@ func (_this *DatabaseDbTree) selfFocus() {
 	_this.app.UI.SetFocus(_this)
 }
 
+// currentDatabase 当前选中节点所在的库，有 schema 层级时返回 SchemaPath 组合的路径
+func (_this *DatabaseDbTree) currentDatabase() string {
+	node := _this.databaseUiTree.GetCurrentNode()
+	for node != nil && node.GetLevel() > 0 {
+		if ref, ok := node.GetReference().(string); ok {
+			return ref
+		}
+		if node.GetLevel() == 1 {
+			return node.GetText()
+		}
+		node = node.GetParentNode()
+	}
+	return _this.selectDB
+}
+
 func (_this *DatabaseDbTree) Init(ctx context.Context) error {
 	// 获取数据库连接配置
 	// 初始化数据库连接
@@ -64,12 +79,33 @@ func (_this *DatabaseDbTree) Init(ctx context.Context) error {
 			// 如果节点已经有子节点，直接返回
 			node.SetExpanded(!node.IsExpanded())
 		} else {
-			// 判断是否是顶级节点
-			if node.GetLevel() == 1 {
+			schemaConn, hasSchema := _this.dbConn.(database_drivers.ISchemaDatabaseConn)
+			switch {
+			case node.GetLevel() == 1 && hasSchema:
+				// 添加 schema 节点
+				schemaList, err := schemaConn.GetSchemaList(selectName)
+				if err != nil {
+					slog.Error("Failed to get schema list", "dbName", selectName, "error", err)
+					_this.app.UI.Flash().Err(err)
+					return
+				}
+				for _, schema := range schemaList {
+					schemaNode := tview.NewTreeNode(schema).
+						SetColor(tcell.ColorGreen).
+						SetSelectable(true).
+						SetReference(database_drivers.SchemaPath(selectName, schema))
+					node.AddChild(schemaNode)
+				}
+			case node.GetLevel() == 1 || (node.GetLevel() == 2 && hasSchema):
 				// 添加表节点
-				tableList, err := _this.dbConn.GetTableList(selectName)
+				dbPath := selectName
+				if ref, ok := node.GetReference().(string); ok {
+					dbPath = ref
+				}
+				tableList, err := _this.dbConn.GetTableList(dbPath)
 				if err != nil {
-					slog.Error("Failed to get table list", "dbName", selectName, "error", err)
+					slog.Error("Failed to get table list", "dbName", dbPath, "error", err)
+					_this.app.UI.Flash().Err(err)
 					return
 				}
 				for _, tableName := range tableList {
@@ -78,10 +114,13 @@ func (_this *DatabaseDbTree) Init(ctx context.Context) error {
 						SetSelectable(true)
 					node.AddChild(tableNode)
 				}
-			} else {
+			default:
 				// 如果是表节点，渲染右侧
 				parentNode := node.GetParentNode()
 				dbName := parentNode.GetText()
+				if ref, ok := parentNode.GetReference().(string); ok {
+					dbName = ref
+				}
 				_this.selectDB = dbName
 
 				tableName := node.GetText()
@@ -0,0 +1,211 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/30 14:00
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// exportProgressRows 每导出多少行刷新一次进度
+const exportProgressRows = 1000
+
+// databaseExportSource 导出的数据来源
+type databaseExportSource struct {
+	name     string   // 默认的文件名和 SQL INSERT 的表名
+	provider string   // 数据库类型，决定 SQL INSERT 的转义方式
+	scopes   []string // 导出范围的选项，依次为当前数据和完整结果
+	total    int      // 完整结果的行数，未知时为 0
+	current  [][]string
+	stream   func(w database_drivers.RowWriter) error // 不分页地读取完整结果
+	done     func()                                   // 导出结束或取消后恢复焦点
+}
+
+// exportProgress 统计已写入的行数并定期刷新进度
+type exportProgress struct {
+	database_drivers.Exporter
+	rows     int
+	progress func(rows int)
+}
+
+func (_this *exportProgress) WriteRow(values []*string) error {
+	if err := _this.Exporter.WriteRow(values); err != nil {
+		return err
+	}
+	_this.rows++
+	if _this.rows%exportProgressRows == 0 {
+		_this.progress(_this.rows)
+	}
+	return nil
+}
+
+// showDatabaseExport 展示导出对话框，确认后异步导出并展示进度
+func showDatabaseExport(app *App, src *databaseExportSource) {
+	dialog.ShowDatabaseExport(&config.Dialog{}, app.Content.Pages, &dialog.DatabaseExportOpts{
+		Message: src.name,
+		Scopes:  src.scopes,
+		Args: dialog.DatabaseExportArgs{
+			Path:   defaultExportPath(src.name, database_drivers.ExportFormatList[0]),
+			Format: database_drivers.ExportFormatList[0],
+			Table:  src.name,
+		},
+		Ack: func(args dialog.DatabaseExportArgs) bool {
+			if args.Path == "" {
+				app.UI.Flash().Err(fmt.Errorf("file is required"))
+				return false
+			}
+			path, err := expandHomePath(args.Path)
+			if err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			file, err := createExportFile(path, args.Overwrite)
+			if err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			exporter, err := database_drivers.NewExporter(args.Format, src.provider, args.Table, file)
+			if err != nil {
+				_ = file.Close()
+				_ = os.Remove(path)
+				app.UI.Flash().Err(err)
+				return false
+			}
+			_runDatabaseExport(app, src, args.Full, exporter, file, path)
+			return true
+		},
+		Cancel: src.done,
+	})
+}
+
+// _runDatabaseExport 异步写入导出文件，失败时删除写了一部分的文件
+func _runDatabaseExport(
+	app *App,
+	src *databaseExportSource,
+	full bool,
+	exporter database_drivers.Exporter,
+	file *os.File,
+	path string,
+) {
+	loading := dialog.ShowLoadingDialog(
+		app.Content.Pages,
+		fmt.Sprintf("⏳ Exporting to %s...", path),
+		app.UI.ForceDraw,
+	)
+	total := len(src.current) - 1
+	if full {
+		total = src.total
+	}
+	w := &exportProgress{
+		Exporter: exporter,
+		progress: func(rows int) {
+			status := fmt.Sprintf("%d rows", rows)
+			if total > 0 {
+				status = fmt.Sprintf("%d / %d rows (%.0f%%)", rows, total, float64(rows)/float64(total)*100)
+			}
+			app.UI.QueueUpdateDraw(func() {
+				loading.SetMessage(fmt.Sprintf("⏳ Exporting to %s\n%s", path, status))
+			})
+		},
+	}
+	go func() {
+		var err error
+		if full {
+			err = src.stream(w)
+		} else {
+			err = database_drivers.ExportRecords(w, src.current)
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			slog.Error("Failed to export", "path", path, "error", err)
+			_ = os.Remove(path)
+		}
+		app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			if err != nil {
+				app.UI.Flash().Err(fmt.Errorf("failed to export: %w", err))
+			} else {
+				app.UI.Flash().Info(fmt.Sprintf("Exported %d rows to %s", w.rows, path))
+			}
+			src.done()
+		})
+	}()
+}
+
+// defaultExportPath 默认导出到当前目录，文件名中的路径分隔符替换为下划线
+func defaultExportPath(name, format string) string {
+	name = strings.NewReplacer("/", "_", `\`, "_").Replace(name)
+	dir, err := os.Getwd()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, name+database_drivers.ExportExt(format))
+}
+
+// createExportFile 创建导出文件，未勾选覆盖时不替换已存在的文件
+func createExportFile(path string, overwrite bool) (*os.File, error) {
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if !overwrite {
+		flag |= os.O_EXCL
+	}
+	file, err := os.OpenFile(path, flag, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return nil, fmt.Errorf("%s already exists, check Overwrite to replace it", path)
+	}
+	return file, err
+}
+
+// exportData 导出当前页，或按当前的过滤条件和排序导出整张表
+func (_this *DatabaseTableComponent) exportData() {
+	if !_this._guardChanges() {
+		return
+	}
+	where, sort := _this.where, database_drivers.OrderBy(_this.dbCfg.Provider, _this.sortColumn, _this.sortDesc)
+	showDatabaseExport(_this.app, &databaseExportSource{
+		name:     _this.tableName,
+		provider: _this.dbCfg.Provider,
+		scopes:   []string{"Current Page", "Full Table"},
+		total:    _this.total,
+		current:  _this.records,
+		stream: func(w database_drivers.RowWriter) error {
+			return _this.dbConn.StreamRecords(_this.dbName, _this.tableName, where, sort, w)
+		},
+		done: _this.focusTable,
+	})
+}
+
+// exportResult 导出当前的查询结果，或重新执行查询导出完整结果
+func (_this *DatabaseQueryView) exportResult() {
+	if _this.query == "" {
+		_this.app.UI.Flash().Warn("Run a query first")
+		return
+	}
+	query, dbName := _this.query, _this.dbName
+	showDatabaseExport(_this.app, &databaseExportSource{
+		name:     "query_result",
+		provider: _this.dbCfg.Provider,
+		scopes:   []string{"Current Result", "Full Result"},
+		current:  _this.records,
+		stream: func(w database_drivers.RowWriter) error {
+			return _this.dbConn.StreamQuery(dbName, query, w)
+		},
+		done: _this.focusTable,
+	})
+}
@@ -0,0 +1,474 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/31 14:00
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/lxz/internal/view/base"
+	"github.com/liangzhaoliang95/tview"
+)
+
+const (
+	// importProgressWidth 进度条的宽度
+	importProgressWidth = 30
+	// importSampleWidth 示例值最多展示的字符数
+	importSampleWidth = 40
+)
+
+// DatabaseImportView 导入向导的第二步，设置列映射和导入方式后执行导入
+type DatabaseImportView struct {
+	*BaseFlex
+	app      *App
+	dbCfg    *config.DBConnection
+	dbConn   database_drivers.IDatabaseConn
+	importer database_drivers.IImportDatabaseConn
+	dbName   string
+	args     dialog.DatabaseImportArgs
+
+	sample        *database_drivers.ImportSample
+	structure     *database_drivers.TableStructure
+	sourceTypes   []string // 文件中每列推断的类型
+	mapping       []int    // 目标列对应的文件中的列，-1 表示使用默认值
+	batchSize     int
+	onDuplicate   string
+	emptyAsNull   bool
+	skipMalformed bool
+	running       bool
+
+	infoUI    *tview.TextView
+	mappingUI *tview.Table
+	reportUI  *tview.TextView
+}
+
+func (_this *DatabaseImportView) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		tcell.KeyEnter:  ui.NewKeyAction("Map Column", _this.EmptyKeyEvent, true),
+		ui.KeyO:         ui.NewKeyAction("Options", _this.OptionsCmd, true),
+		tcell.KeyCtrlS:  ui.NewKeyAction("Start Import", _this.StartCmd, true),
+		tcell.KeyTAB:    ui.NewKeyAction("Focus Change", _this.TabFocusChange, true),
+		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
+	})
+}
+
+func (_this *DatabaseImportView) TabFocusChange(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.app.UI.GetFocus() == _this.mappingUI {
+		_this.app.UI.SetFocus(_this.reportUI)
+	} else {
+		_this.focusMapping()
+	}
+	return nil
+}
+
+// OptionsCmd 设置导入方式
+func (_this *DatabaseImportView) OptionsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.running {
+		return nil
+	}
+	dialog.ShowDatabaseImportOptions(&config.Dialog{}, _this.app.Content.Pages, &dialog.DatabaseImportOptionsOpts{
+		BatchSize:     _this.batchSize,
+		OnDuplicate:   _this.onDuplicate,
+		EmptyAsNull:   _this.emptyAsNull,
+		SkipMalformed: _this.skipMalformed,
+		Ack: func(batchSize int, onDuplicate string, emptyAsNull, skipMalformed bool) bool {
+			if batchSize <= 0 {
+				_this.app.UI.Flash().Err(fmt.Errorf("batch size must be greater than 0"))
+				return false
+			}
+			_this.batchSize, _this.onDuplicate, _this.emptyAsNull = batchSize, onDuplicate, emptyAsNull
+			_this.skipMalformed = skipMalformed
+			_this._renderInfo()
+			return true
+		},
+		Cancel: _this.focusMapping,
+	})
+	return nil
+}
+
+// StartCmd 确认后开始导入
+func (_this *DatabaseImportView) StartCmd(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.running {
+		_this.app.UI.Flash().Warn("Import is running")
+		return nil
+	}
+	opts := _this._importOptions()
+	if len(opts.Columns) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("map at least one column"))
+		return nil
+	}
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Start Import",
+		fmt.Sprintf("Import %s into %s with %d columns?", _this.args.Path, _this.args.Table, len(opts.Columns)),
+		func(force bool) {
+			_this._runImport(opts)
+		},
+		_this.focusMapping)
+	return nil
+}
+
+func (_this *DatabaseImportView) focusMapping() {
+	_this.app.UI.SetFocus(_this.mappingUI)
+}
+
+func (_this *DatabaseImportView) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+
+	iDatabaseConn, err := database_drivers.GetConnectOrInit(_this.dbCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	importer, ok := iDatabaseConn.(database_drivers.IImportDatabaseConn)
+	if !ok {
+		return fmt.Errorf("import is not supported for %s", _this.dbCfg.Provider)
+	}
+	_this.dbConn, _this.importer = iDatabaseConn, importer
+
+	_this.sample, err = database_drivers.ReadImportSample(_this.args.Path, _this.args.Format, database_drivers.ImportSampleRows)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", _this.args.Path, err)
+	}
+	_this.structure, err = _this.dbConn.GetTableStructure(_this.dbName, _this.args.Table)
+	if err != nil {
+		return fmt.Errorf("failed to get table structure: %w", err)
+	}
+	_this.sourceTypes = make([]string, len(_this.sample.Columns))
+	for i := range _this.sample.Columns {
+		_this.sourceTypes[i] = database_drivers.InferColumnType(_this.sample.Values(i))
+	}
+	// 默认按列名映射，忽略大小写
+	_this.mapping = make([]int, len(_this.structure.Columns))
+	for i, c := range _this.structure.Columns {
+		_this.mapping[i] = -1
+		for j, source := range _this.sample.Columns {
+			if strings.EqualFold(c.Name, strings.TrimSpace(source)) {
+				_this.mapping[i] = j
+				break
+			}
+		}
+	}
+
+	_this.infoUI = tview.NewTextView()
+	_this.infoUI.SetDynamicColors(true).
+		SetBorderPadding(0, 0, 1, 1)
+
+	_this.mappingUI = tview.NewTable()
+	_this.mappingUI.SetBorders(true)
+	_this.mappingUI.SetSeparator(tview.Borders.Vertical)
+	_this.mappingUI.SetSelectable(true, false)
+	_this.mappingUI.SetFixed(1, 0)
+	_this.mappingUI.SetSelectedStyle(
+		tcell.StyleDefault.Background(tcell.ColorRed).
+			Foreground(tview.Styles.ContrastSecondaryTextColor),
+	)
+	_this.mappingUI.SetSelectedFunc(func(row, _ int) {
+		_this._mapColumn(row - 1)
+	})
+
+	_this.reportUI = tview.NewTextView()
+	_this.reportUI.SetDynamicColors(true).
+		SetScrollable(true).
+		SetBorderPadding(0, 0, 1, 1).
+		SetBorder(true).
+		SetTitle(" Report ")
+	_this.reportUI.SetFocusFunc(func() {
+		_this.reportUI.SetBorderColor(base.ActiveBorderColor)
+	})
+	_this.reportUI.SetBlurFunc(func() {
+		_this.reportUI.SetBorderColor(base.InactiveBorderColor)
+	})
+	_this.reportUI.SetText("[gray]Press Enter to map a column, o to change the options and Ctrl-S to start the import")
+
+	_this.AddItem(_this.infoUI, 2, 0, false)
+	_this.AddItem(_this.mappingUI, 0, 2, true)
+	_this.AddItem(_this.reportUI, 0, 1, false)
+	return nil
+}
+
+func (_this *DatabaseImportView) Start() {
+	_this._renderInfo()
+	_this._renderMapping(1)
+}
+
+func (_this *DatabaseImportView) Stop() {
+
+}
+
+// _renderInfo 展示文件、目标表和导入方式
+func (_this *DatabaseImportView) _renderInfo() {
+	text := fmt.Sprintf("[aqua]File[-] %s  [aqua]Format[-] %s  [aqua]Columns[-] %d  [aqua]Sampled[-] %d rows",
+		tview.Escape(_this.args.Path), _this.args.Format, len(_this.sample.Columns), len(_this.sample.Rows))
+	if n := len(_this.sample.Errors); n > 0 {
+		text += fmt.Sprintf("  [red]%d malformed[-]", n)
+	}
+	text += fmt.Sprintf("\n[aqua]Table[-] %s  [aqua]Batch Size[-] %d  [aqua]On Duplicate[-] %s  [aqua]Empty As NULL[-] %s  [aqua]Skip Malformed[-] %s",
+		tview.Escape(_this.args.Table), _this.batchSize, _this.onDuplicate, yesNo(_this.emptyAsNull), yesNo(_this.skipMalformed))
+	_this.infoUI.SetText(text)
+}
+
+// _renderMapping 每行一个目标列，展示映射的文件中的列、推断的类型、示例值和检查结果
+func (_this *DatabaseImportView) _renderMapping(row int) {
+	rows := [][]string{{"Column", "Type", "Source", "Detected", "Sample", "Check"}}
+	for i, c := range _this.structure.Columns {
+		source, detected, sample := "(default)", "", ""
+		if src := _this.mapping[i]; src >= 0 {
+			source, detected = _this.sample.Columns[src], _this.sourceTypes[src]
+			sample = "NULL&"
+			if values := _this.sample.Values(src); len(values) > 0 && values[0] != nil {
+				sample = importSampleText(*values[0])
+			}
+		}
+		rows = append(rows, []string{c.Name, c.Type, source, detected, sample, _this._checkColumn(i)})
+	}
+	_this.mappingUI.Clear()
+	TableAddRows(_this.mappingUI, rows)
+	for r := 1; r < len(rows); r++ {
+		cell := _this.mappingUI.GetCell(r, len(rows[0])-1)
+		switch cell.Text {
+		case "OK":
+			cell.SetTextColor(tcell.ColorGreen)
+		case "Required":
+			cell.SetTextColor(tcell.ColorRed)
+		default:
+			cell.SetTextColor(tcell.ColorOrange)
+		}
+	}
+	_this.mappingUI.Select(min(max(row, 1), len(rows)-1), 0)
+}
+
+// _checkColumn 检查目标列的映射，未映射的非空列必须有默认值或自增
+func (_this *DatabaseImportView) _checkColumn(i int) string {
+	c := _this.structure.Columns[i]
+	src := _this.mapping[i]
+	if src < 0 {
+		if !c.Nullable && c.Default == nil && !strings.Contains(strings.ToLower(c.Extra), "auto_increment") {
+			return "Required"
+		}
+		return "OK"
+	}
+	if !database_drivers.ImportTypeCompatible(_this.sourceTypes[src], c.Type) {
+		return "Type mismatch"
+	}
+	return "OK"
+}
+
+// _mapColumn 修改目标列映射的文件中的列
+func (_this *DatabaseImportView) _mapColumn(i int) {
+	if i < 0 || i >= len(_this.structure.Columns) || _this.running {
+		return
+	}
+	sources := make([]string, len(_this.sample.Columns))
+	for j, c := range _this.sample.Columns {
+		sources[j] = fmt.Sprintf("%s (%s)", c, _this.sourceTypes[j])
+	}
+	dialog.ShowDatabaseImportColumn(&config.Dialog{}, _this.app.Content.Pages, &dialog.DatabaseImportColumnOpts{
+		Column:  _this.structure.Columns[i].Name,
+		Sources: sources,
+		Source:  _this.mapping[i],
+		Ack: func(source int) bool {
+			_this.mapping[i] = source
+			_this._renderMapping(i + 1)
+			return true
+		},
+		Cancel: _this.focusMapping,
+	})
+}
+
+// _importOptions 按映射生成导入参数，未映射的列不写入
+func (_this *DatabaseImportView) _importOptions() database_drivers.ImportOptions {
+	opts := database_drivers.ImportOptions{
+		BatchSize:     _this.batchSize,
+		OnDuplicate:   _this.onDuplicate,
+		EmptyAsNull:   _this.emptyAsNull,
+		SkipMalformed: _this.skipMalformed,
+	}
+	for i, c := range _this.structure.Columns {
+		if _this.mapping[i] >= 0 {
+			opts.Columns = append(opts.Columns, c.Name)
+			opts.Sources = append(opts.Sources, _this.mapping[i])
+		}
+	}
+	return opts
+}
+
+// _runImport 异步导入并展示进度，结束后输出报告
+func (_this *DatabaseImportView) _runImport(opts database_drivers.ImportOptions) {
+	file, err := database_drivers.OpenImportFile(_this.args.Path, _this.args.Format)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	_this.running = true
+	title := fmt.Sprintf("⏳ Importing into %s", _this.args.Table)
+	loading := dialog.ShowLoadingDialog(_this.app.Content.Pages, title+"...", _this.app.UI.ForceDraw)
+	go func() {
+		report, err := _this.importer.ImportRows(_this.dbName, _this.args.Table, file, opts,
+			func(r database_drivers.ImportReport) {
+				ratio := file.Progress()
+				_this.app.UI.QueueUpdateDraw(func() {
+					loading.SetMessage(fmt.Sprintf("%s\n%s %3.0f%%  %d rows",
+						title, progressBar(ratio, importProgressWidth), ratio*100, r.Rows))
+				})
+			})
+		_ = file.Close()
+		_this.app.UI.QueueUpdateDraw(func() {
+			_this.running = false
+			loading.Hide()
+			_this.reportUI.SetText(formatImportReport(report, err, opts.OnDuplicate))
+			_this.reportUI.ScrollToBeginning()
+			if err != nil {
+				_this.app.UI.Flash().Err(fmt.Errorf("import failed, all changes were rolled back: %w", err))
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Imported %d rows into %s", report.Rows, _this.args.Table))
+			}
+			_this.focusMapping()
+		})
+	}()
+}
+
+// formatImportReport 导入报告，失败时所有写入都已回滚
+func formatImportReport(report *database_drivers.ImportReport, err error, onDuplicate string) string {
+	if report == nil {
+		report = &database_drivers.ImportReport{}
+	}
+	var b strings.Builder
+	if err != nil {
+		b.WriteString("[red::b]Import failed, all changes were rolled back[-::-]\n")
+		_, _ = fmt.Fprintf(&b, "[red]%s[-]\n", tview.Escape(err.Error()))
+		_, _ = fmt.Fprintf(&b, "Rows written before the failure: %d\n", report.Rows)
+	} else {
+		b.WriteString("[green::b]Import finished[-::-]\n")
+		_, _ = fmt.Fprintf(&b, "Rows written: %d\n", report.Rows)
+	}
+	switch onDuplicate {
+	case database_drivers.ImportSkip:
+		_, _ = fmt.Fprintf(&b, "Inserted: %d  Skipped: %d\n", report.Affected, report.Skipped)
+	case database_drivers.ImportUpdate:
+		_, _ = fmt.Fprintf(&b, "Affected rows: %d (an updated row counts as 2)\n", report.Affected)
+	}
+	_, _ = fmt.Fprintf(&b, "Malformed rows: %d\n", report.ErrorCount)
+	if report.ErrorCount > 0 {
+		_, _ = fmt.Fprintf(&b, "\n[yellow]Malformed rows (first %d)[-]\n", len(report.Errors))
+		for _, e := range report.Errors {
+			b.WriteString(tview.Escape(e.Error()) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// importSampleText 示例值合并为一行并截断，避免撑开表格
+func importSampleText(v string) string {
+	if v == "" {
+		return "EMPTY&"
+	}
+	runes := []rune(strings.Join(strings.Fields(v), " "))
+	if len(runes) > importSampleWidth {
+		return string(runes[:importSampleWidth]) + "…"
+	}
+	return string(runes)
+}
+
+// progressBar 文本进度条，ratio 为 0 到 1
+func progressBar(ratio float64, width int) string {
+	filled := min(max(int(ratio*float64(width)), 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// showDatabaseImport 导入向导的第一步，可以从文件浏览器中选择文件，选择后回到这一步
+func showDatabaseImport(
+	app *App,
+	dbCfg *config.DBConnection,
+	dbName string,
+	tables []string,
+	args dialog.DatabaseImportArgs,
+	done func(),
+) {
+	dialog.ShowDatabaseImport(&config.Dialog{}, app.Content.Pages, &dialog.DatabaseImportOpts{
+		Tables: tables,
+		Args:   args,
+		Browse: func(args dialog.DatabaseImportArgs) {
+			picker := NewFilePicker(app, func(path string) {
+				args.Path, args.Format = path, database_drivers.ImportFormatOf(path)
+				showDatabaseImport(app, dbCfg, dbName, tables, args, done)
+			})
+			if err := app.inject(picker, false); err != nil {
+				app.UI.Flash().Err(fmt.Errorf("failed to open the file browser: %w", err))
+			}
+		},
+		Ack: func(args dialog.DatabaseImportArgs) bool {
+			if args.Path == "" || args.Table == "" {
+				app.UI.Flash().Err(fmt.Errorf("file and table are required"))
+				return false
+			}
+			path, err := expandHomePath(args.Path)
+			if err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			if _, err = os.Stat(path); err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			args.Path = path
+			if err := app.inject(NewDatabaseImportView(app, dbCfg, dbName, args), false); err != nil {
+				app.UI.Flash().Err(err)
+			}
+			return true
+		},
+		Cancel: done,
+	})
+}
+
+// importData 打开导入向导，目前只支持 MySQL
+func (_this *DatabaseTableComponent) importData() {
+	if _, ok := _this.dbConn.(database_drivers.IImportDatabaseConn); !ok {
+		_this.app.UI.Flash().Warn("Import is only supported for MySQL")
+		return
+	}
+	if !_this._guardChanges() {
+		return
+	}
+	tables, err := _this.dbConn.GetTableList(_this.dbName)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	showDatabaseImport(_this.app, _this.dbCfg, _this.dbName, tables,
+		dialog.DatabaseImportArgs{Table: _this.tableName}, _this.focusCurrent)
+}
+
+func NewDatabaseImportView(
+	a *App,
+	dbCfg *config.DBConnection,
+	dbName string,
+	args dialog.DatabaseImportArgs,
+) *DatabaseImportView {
+	var name = "Import " + args.Table
+	v := DatabaseImportView{
+		BaseFlex:    NewBaseFlex(name),
+		app:         a,
+		dbCfg:       dbCfg,
+		dbName:      dbName,
+		args:        args,
+		batchSize:   database_drivers.DefaultImportBatchSize,
+		onDuplicate: database_drivers.ImportDuplicateList[0],
+		emptyAsNull: true,
+	}
+	v.SetDirection(tview.FlexRow)
+	v.SetBorder(true)
+
+	v.SetBorderColor(base.BoarderDefaultColor)
+	return &v
+}
@@ -64,12 +64,82 @@ func (_this *DatabaseMainPage) bindKeys() {
 		tcell.KeyCtrlO:  ui.NewKeyAction("Open Query Page", _this.goToQueryPage, true),
 		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
 		tcell.KeyTAB:    ui.NewKeyAction("Focus Change", _this.TabFocusChange, true),
+		ui.Key1: ui.NewKeyAction("Data", _this.tableCmd(func(c *DatabaseTableComponent) {
+			c.switchTab(dbTabData)
+		}), true),
+		ui.Key2: ui.NewKeyAction("Structure", _this.tableCmd(func(c *DatabaseTableComponent) {
+			c.switchTab(dbTabStructure)
+		}), true),
+		ui.KeyRightBracket: ui.NewKeyAction("Next Page", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.nextPage()
+		}), true),
+		ui.KeyLeftBracket: ui.NewKeyAction("Prev Page", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.prevPage()
+		}), true),
+		ui.KeyG: ui.NewKeyAction("Go To Page", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.jumpPage()
+		}), true),
+		ui.KeyS: ui.NewKeyAction("Sort Column", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.toggleSort()
+		}), true),
+		ui.KeyE: ui.NewKeyAction("Edit Cell", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.editCell()
+		}), true),
+		tcell.KeyCtrlN: ui.NewKeyAction("New Row", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.insertRow()
+		}), true),
+		tcell.KeyCtrlD: ui.NewKeyAction("Delete Row", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.deleteRow()
+		}), true),
+		ui.KeyU: ui.NewKeyAction("Undo Row", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.undoRow()
+		}), true),
+		ui.KeyShiftU: ui.NewKeyAction("Discard Changes", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.discardChanges()
+		}), true),
+		tcell.KeyCtrlS: ui.NewKeyAction("Commit Changes", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.commitChanges()
+		}), true),
+		ui.KeyX: ui.NewKeyAction("Export", _this.dataCmd(func(c *DatabaseTableComponent) {
+			c.exportData()
+		}), true),
+		ui.KeyI: ui.NewKeyAction("Import", _this.tableCmd(func(c *DatabaseTableComponent) {
+			c.importData()
+		}), true),
 	})
 }
 
+// dataCmd 只在数据标签页中生效的按键
+func (_this *DatabaseMainPage) dataCmd(fn func(c *DatabaseTableComponent)) func(evt *tcell.EventKey) *tcell.EventKey {
+	return _this.tableCmd(func(c *DatabaseTableComponent) {
+		if c.currentTab != dbTabData {
+			appUiInstance.Flash().Warn("Switch to the data tab first")
+			return
+		}
+		fn(c)
+	})
+}
+
+// tableCmd 将按键转发给当前的表格组件，输入框中的按键不处理
+func (_this *DatabaseMainPage) tableCmd(fn func(c *DatabaseTableComponent)) func(evt *tcell.EventKey) *tcell.EventKey {
+	return func(evt *tcell.EventKey) *tcell.EventKey {
+		if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+			return evt
+		}
+		currentPage := _this.tableView.tableComponents[_this.tableView.currentPageKey]
+		if currentPage == nil {
+			appUiInstance.Flash().Err(fmt.Errorf("select one table first"))
+			return nil
+		}
+		fn(currentPage)
+		return nil
+	}
+}
+
 func (_this *DatabaseMainPage) goToQueryPage(evt *tcell.EventKey) *tcell.EventKey {
-	// 跳到手动查询页面
-	if err := _this.app.inject(NewDatabaseQueryView(_this.app, _this.dbConnCfg), false); err != nil {
+	// 跳到手动查询页面，查询在左侧选中的库中执行
+	queryView := NewDatabaseQueryView(_this.app, _this.dbConnCfg, _this.dbTree.currentDatabase())
+	if err := _this.app.inject(queryView, false); err != nil {
 		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject database query view: %w", err))
 	}
 	return nil
@@ -23,10 +23,13 @@ type DatabaseQueryView struct {
 	app    *App
 	dbCfg  *config.DBConnection // 数据库连接配置
 	dbConn database_drivers.IDatabaseConn
+	dbName string // 执行查询的库，有 schema 层级时为 SchemaPath 组合的路径
 
 	filterInput *tview.InputField // 用于输入过滤条件
 	dataTable   *tview.Table      // 用于显示表数据
 
+	query   string     // 最近一次执行成功的查询
+	records [][]string // 最近一次查询的结果，第一行为列名
 }
 
 func (_this *DatabaseQueryView) TabFocusChange(event *tcell.EventKey) *tcell.EventKey {
@@ -44,9 +47,19 @@ func (_this *DatabaseQueryView) bindKeys() {
 		ui.KeySlash:     ui.NewKeyAction("Search", _this.ToggleSearch, true),
 		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
 		tcell.KeyTAB:    ui.NewKeyAction("Focus Change", _this.TabFocusChange, true),
+		ui.KeyX:         ui.NewKeyAction("Export", _this.ExportCmd, true),
 	})
 }
 
+// ExportCmd 导出查询结果
+func (_this *DatabaseQueryView) ExportCmd(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.exportResult()
+	return nil
+}
+
 // ToggleSearch
 func (_this *DatabaseQueryView) ToggleSearch(evt *tcell.EventKey) *tcell.EventKey {
 	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
@@ -113,6 +126,7 @@ func (_this *DatabaseQueryView) Init(ctx context.Context) error {
 			// 初始化表格数据
 			// loading := dialog.ShowLoadingDialog(_this.app.Content.Pages, "", _this.app.UI.Draw)
 			records, _, err := _this.dbConn.ExecuteQuery(
+				_this.dbName,
 				whereClause,
 			)
 			slog.Info("Executing query with where clause: " + whereClause)
@@ -121,6 +135,7 @@ func (_this *DatabaseQueryView) Init(ctx context.Context) error {
 					Err(fmt.Errorf("%w", err))
 				_this.focusSearch()
 			} else {
+				_this.query, _this.records = whereClause, records
 				_this.SetTableData(records)
 				_this.focusTable()
 			}
@@ -166,12 +181,14 @@ func (_this *DatabaseQueryView) Stop() {
 func NewDatabaseQueryView(
 	a *App,
 	dbCfg *config.DBConnection,
+	dbName string,
 ) *DatabaseQueryView {
 	var name = "Manul Query"
 	lp := DatabaseQueryView{
 		BaseFlex: NewBaseFlex(name),
 		app:      a,
 		dbCfg:    dbCfg,
+		dbName:   dbName,
 	}
 	lp.SetDirection(tview.FlexRow)
 	lp.SetBorder(true)
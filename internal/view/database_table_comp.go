@@ -14,6 +14,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/config"
 	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/slogs"
 	"github.com/liangzhaoliang95/lxz/internal/view/base"
 	"github.com/liangzhaoliang95/tview"
 )
@@ -26,14 +27,36 @@ type DatabaseTableComponent struct {
 	dbCfg     *config.DBConnection
 	dbConn    database_drivers.IDatabaseConn // 数据库连接接口
 	// ui组件
-	filterFlex  *tview.Flex       // 用于布局过滤条件输入框和标签
-	filterLabel *tview.TextView   // 用于显示过滤条件标签
-	filterInput *tview.InputField // 用于输入过滤条件
-	dataTable   *tview.Table      // 用于显示表数据
-
+	tabBarUI        *tview.TextView   // 标签栏
+	tabPagesUI      *tview.Pages      // 数据和表结构标签页
+	structureViewUI *tview.TextView   // 表结构
+	currentTab      int               // 当前的标签页
+	dataFlex        *tview.Flex       // 数据标签页，包含过滤条件、表格和分页信息
+	filterFlex      *tview.Flex       // 用于布局过滤条件输入框和标签
+	filterLabel     *tview.TextView   // 用于显示过滤条件标签
+	filterInput     *tview.InputField // 用于输入过滤条件
+	dataTable       *tview.Table      // 用于显示表数据
+	footer          *tview.TextView   // 用于显示分页和排序信息
+	// 分页和排序
+	where      string   // 当前的过滤条件
+	pageSize   int      // 每页行数
+	page       int      // 当前页，从 0 开始
+	total      int      // 满足过滤条件的总行数
+	columns    []string // 当前结果的列名
+	sortColumn string   // 排序列，为空时不排序
+	sortDesc   bool     // 是否降序
+	// 编辑
+	primaryKeys []string               // 主键列，没有主键时不能修改和删除已有的行
+	records     [][]string             // 当前页的数据，第一行为列名
+	edits       map[int]map[int]string // 修改的单元格，行 -> 列 -> 新值
+	deletes     map[int]bool           // 标记删除的行
+	inserts     [][]string             // 新增的行
 }
 
 func (_this *DatabaseTableComponent) focusSearch() {
+	if _this.currentTab != dbTabData {
+		_this.switchTab(dbTabData)
+	}
 	// 设置当前焦点为搜索框
 	_this.app.UI.SetFocus(_this.filterFlex)
 	_this.filterFlex.SetBorderColor(base.ActiveBorderColor)
@@ -61,6 +84,11 @@ func (_this *DatabaseTableComponent) Init(ctx context.Context) error {
 		return fmt.Errorf("failed to get database connection: %w", err)
 	}
 	_this.dbConn = iDatabaseConn
+	_this._loadPrimaryKeys()
+	_this._clearChanges()
+
+	// 初始化标签页，数据标签页包含过滤条件、表格和分页信息
+	_this._initTabs()
 
 	// 初始化filterFlex
 	_this.filterFlex = tview.NewFlex()
@@ -90,20 +118,19 @@ func (_this *DatabaseTableComponent) Init(ctx context.Context) error {
 	_this.filterInput.SetDoneFunc(func(key tcell.Key) {
 		switch key {
 		case tcell.KeyEnter:
+			if !_this._guardChanges() {
+				return
+			}
 			whereClause := ""
 			if _this.filterInput.GetText() != "" {
 				whereClause = fmt.Sprintf("WHERE %s", _this.filterInput.GetText())
 			}
-			// 初始化表格数据
-			records, _, err := _this.dbConn.GetRecords(
-				_this.dbName,
-				_this.tableName,
-				whereClause,
-				"",
-				0,
-				0,
-			)
+			// 过滤条件变化后回到第一页
+			where, page := _this.where, _this.page
+			_this.where, _this.page = whereClause, 0
+			records, err := _this._fetchPage()
 			if err != nil {
+				_this.where, _this.page = where, page
 				slog.Error(
 					"Failed to get records for table",
 					"tableName",
@@ -126,7 +153,7 @@ func (_this *DatabaseTableComponent) Init(ctx context.Context) error {
 		}
 	})
 	_this.filterFlex.AddItem(_this.filterInput, 0, 5, true)
-	_this.AddItem(_this.filterFlex, 3, 1, false)
+	_this.dataFlex.AddItem(_this.filterFlex, 3, 1, false)
 
 	// 初始化表格
 	_this.dataTable = tview.NewTable()
@@ -137,22 +164,34 @@ func (_this *DatabaseTableComponent) Init(ctx context.Context) error {
 		tcell.StyleDefault.Background(tcell.ColorRed).
 			Foreground(tview.Styles.ContrastSecondaryTextColor),
 	)
-	_this.dataTable.SetSelectable(true, false)
+	_this.dataTable.SetSelectable(true, true)
 	_this.dataTable.SetFixed(1, 0)
-	_this.AddItem(_this.dataTable, 0, 7, true)
+	_this.dataTable.SetSelectedFunc(func(row, column int) {
+		_this.editCell()
+	})
+	_this.dataFlex.AddItem(_this.dataTable, 0, 7, true)
+
+	// 初始化footer
+	_this.footer = tview.NewTextView()
+	_this.footer.SetDynamicColors(true)
+	_this.footer.SetTextAlign(tview.AlignRight)
+	_this.footer.SetBorderPadding(0, 0, 1, 1)
+	_this.dataFlex.AddItem(_this.footer, 1, 0, false)
 	return nil
 }
 
 func (_this *DatabaseTableComponent) Start() {
+	if _this.currentTab == dbTabStructure {
+		_this._refreshStructure()
+	}
+	// 有未提交的修改时保留当前页
+	if _this._hasChanges() {
+		row, _ := _this.dataTable.GetSelection()
+		_this._render(row)
+		return
+	}
 	// 初始化表格数据
-	records, _, err := _this.dbConn.GetRecords(
-		_this.dbName,
-		_this.tableName,
-		"",
-		"",
-		0,
-		0,
-	)
+	records, err := _this._fetchPage()
 	if err != nil {
 		_this.app.UI.Flash().
 			Err(fmt.Errorf("failed to get records for table %s: %w", _this.tableName, err))
@@ -197,9 +236,9 @@ func TableAddRows(tableView *tview.Table, rows [][]string) {
 // SetTableData 设置表格数据
 func (_this *DatabaseTableComponent) SetTableData(rows [][]string) {
 	// 清空旧数据
-	_this.dataTable.Clear()
-	TableAddRows(_this.dataTable, rows)
-	_this.dataTable.Select(1, 1)
+	_this.records = rows
+	_this._clearChanges()
+	_this._render(1)
 }
 
 func NewDatabaseTableComponent(
@@ -215,6 +254,14 @@ func NewDatabaseTableComponent(
 		dbName:    dbName,
 		tableName: tableName,
 		dbCfg:     dbCfg,
+		pageSize:  database_drivers.DefaultRowLimit,
+	}
+	databaseCfg, err := readConfiguration()
+	if err != nil {
+		slog.Error("Failed to load database configuration", slogs.Error, err)
+	}
+	if databaseCfg.DefaultPageSize > 0 {
+		lp.pageSize = databaseCfg.DefaultPageSize
 	}
 	lp.SetDirection(tview.FlexRow)
 	lp.SetBorder(false)
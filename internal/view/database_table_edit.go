@@ -0,0 +1,395 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/28 15:00
+ */
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/slogs"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// 表格中未提交修改的颜色
+const (
+	dbEditedColor   = tcell.ColorOrange
+	dbInsertedColor = tcell.ColorGreen
+	dbDeletedColor  = tcell.ColorRed
+)
+
+// 单元格中表示空值和默认值的占位符，与驱动返回的数据一致
+const (
+	dbNullCell    = "NULL&"
+	dbEmptyCell   = "EMPTY&"
+	dbDefaultCell = "DEFAULT&"
+)
+
+// _loadPrimaryKeys 获取主键，修改和删除已有的行需要按主键定位
+func (_this *DatabaseTableComponent) _loadPrimaryKeys() {
+	keys, err := _this.dbConn.GetPrimaryKeys(_this.dbName, _this.tableName)
+	if err != nil {
+		slog.Warn("Failed to get primary keys", "tableName", _this.tableName, slogs.Error, err)
+		return
+	}
+	_this.primaryKeys = keys
+}
+
+// _hasChanges 是否有未提交的修改
+func (_this *DatabaseTableComponent) _hasChanges() bool {
+	return len(_this.edits) > 0 || len(_this.deletes) > 0 || len(_this.inserts) > 0
+}
+
+// _pendingCount 未提交修改的行数
+func (_this *DatabaseTableComponent) _pendingCount() int {
+	count := len(_this.deletes) + len(_this.inserts)
+	for row := range _this.edits {
+		if !_this.deletes[row] {
+			count++
+		}
+	}
+	return count
+}
+
+// _clearChanges 丢弃所有未提交的修改
+func (_this *DatabaseTableComponent) _clearChanges() {
+	_this.edits = make(map[int]map[int]string)
+	_this.deletes = make(map[int]bool)
+	_this.inserts = nil
+}
+
+// _insertIndex 表格行对应的新增行下标，不是新增行时返回 -1
+func (_this *DatabaseTableComponent) _insertIndex(row int) int {
+	if row >= len(_this.records) && row-len(_this.records) < len(_this.inserts) {
+		return row - len(_this.records)
+	}
+	return -1
+}
+
+// _cell 单元格当前的值，包含未提交的修改
+func (_this *DatabaseTableComponent) _cell(row, col int) string {
+	if i := _this._insertIndex(row); i >= 0 {
+		return _this.inserts[i][col]
+	}
+	if v, ok := _this.edits[row][col]; ok {
+		return v
+	}
+	return _this.records[row][col]
+}
+
+// _render 按当前页的数据和未提交的修改重新渲染表格，修改的单元格、新增和删除的行用不同颜色区分
+func (_this *DatabaseTableComponent) _render(row int) {
+	_, column := _this.dataTable.GetSelection()
+	rows := make([][]string, 0, len(_this.records)+len(_this.inserts))
+	for i := range _this.records {
+		cells := slices.Clone(_this.records[i])
+		if i > 0 {
+			for col := range cells {
+				cells[col] = _this._cell(i, col)
+			}
+		}
+		rows = append(rows, cells)
+	}
+	rows = append(rows, _this.inserts...)
+
+	_this.dataTable.Clear()
+	TableAddRows(_this.dataTable, rows)
+	_this._decorateHeader()
+	for r, cols := range _this.edits {
+		for col := range cols {
+			_this.dataTable.GetCell(r, col).SetTextColor(dbEditedColor)
+		}
+	}
+	for r := range _this.deletes {
+		for col := 0; col < len(_this.columns); col++ {
+			_this.dataTable.GetCell(r, col).
+				SetTextColor(dbDeletedColor).
+				SetAttributes(tcell.AttrStrikeThrough)
+		}
+	}
+	for i := range _this.inserts {
+		for col := 0; col < len(_this.columns); col++ {
+			_this.dataTable.GetCell(len(_this.records)+i, col).SetTextColor(dbInsertedColor)
+		}
+	}
+	_this.dataTable.Select(
+		max(min(row, _this.dataTable.GetRowCount()-1), 1),
+		max(min(column, _this.dataTable.GetColumnCount()-1), 0),
+	)
+	_this._updateFooter()
+}
+
+// _guardChanges 有未提交的修改时不允许翻页、排序和过滤，避免修改丢失
+func (_this *DatabaseTableComponent) _guardChanges() bool {
+	if _this._hasChanges() {
+		_this.app.UI.Flash().Warn("Commit or discard the pending changes first")
+		return false
+	}
+	return true
+}
+
+// editCell 编辑选中的单元格
+func (_this *DatabaseTableComponent) editCell() {
+	row, col := _this.dataTable.GetSelection()
+	if row < 1 || col < 0 || col >= len(_this.columns) || row >= _this.dataTable.GetRowCount() {
+		return
+	}
+	insertIndex := _this._insertIndex(row)
+	if insertIndex < 0 {
+		if len(_this.primaryKeys) == 0 {
+			_this.app.UI.Flash().Err(fmt.Errorf("table %s has no primary key, existing rows cannot be edited", _this.tableName))
+			return
+		}
+		if _this.deletes[row] {
+			_this.app.UI.Flash().Warn("The row is marked for deletion")
+			return
+		}
+	}
+
+	current := _this._cell(row, col)
+	value, null := current, current == dbNullCell
+	if current == dbNullCell || current == dbEmptyCell || current == dbDefaultCell {
+		value = ""
+	}
+	dialog.ShowDatabaseEditCell(&config.Dialog{}, _this.app.Content.Pages, &dialog.DatabaseEditCellOpts{
+		Column: _this.columns[col],
+		Value:  value,
+		Null:   null,
+		Ack: func(value string, null bool) bool {
+			cell := value
+			switch {
+			case null:
+				cell = dbNullCell
+			case value == "":
+				cell = dbEmptyCell
+			}
+			if insertIndex >= 0 {
+				_this.inserts[insertIndex][col] = cell
+			} else if cell == _this.records[row][col] {
+				// 改回原值时不再视为修改
+				delete(_this.edits[row], col)
+				if len(_this.edits[row]) == 0 {
+					delete(_this.edits, row)
+				}
+			} else {
+				if _this.edits[row] == nil {
+					_this.edits[row] = make(map[int]string)
+				}
+				_this.edits[row][col] = cell
+			}
+			_this._render(row)
+			return true
+		},
+		Cancel: func() {
+			_this.focusTable()
+		},
+	})
+}
+
+// insertRow 在表格末尾新增一行，所有列默认使用数据库的默认值
+func (_this *DatabaseTableComponent) insertRow() {
+	if len(_this.columns) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("columns of table %s are unknown", _this.tableName))
+		return
+	}
+	row := make([]string, len(_this.columns))
+	for i := range row {
+		row[i] = dbDefaultCell
+	}
+	_this.inserts = append(_this.inserts, row)
+	_this._render(len(_this.records) + len(_this.inserts) - 1)
+}
+
+// deleteRow 标记或取消标记删除选中的行，新增的行直接移除
+func (_this *DatabaseTableComponent) deleteRow() {
+	row, _ := _this.dataTable.GetSelection()
+	if row < 1 || row >= _this.dataTable.GetRowCount() {
+		return
+	}
+	if i := _this._insertIndex(row); i >= 0 {
+		_this.inserts = slices.Delete(_this.inserts, i, i+1)
+		_this._render(row)
+		return
+	}
+	if len(_this.primaryKeys) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("table %s has no primary key, existing rows cannot be deleted", _this.tableName))
+		return
+	}
+	if _this.deletes[row] {
+		delete(_this.deletes, row)
+	} else {
+		_this.deletes[row] = true
+	}
+	_this._render(row + 1)
+}
+
+// undoRow 撤销选中行的修改
+func (_this *DatabaseTableComponent) undoRow() {
+	row, _ := _this.dataTable.GetSelection()
+	if i := _this._insertIndex(row); i >= 0 {
+		_this.inserts = slices.Delete(_this.inserts, i, i+1)
+	} else {
+		delete(_this.edits, row)
+		delete(_this.deletes, row)
+	}
+	_this._render(row)
+}
+
+// discardChanges 确认后丢弃所有未提交的修改
+func (_this *DatabaseTableComponent) discardChanges() {
+	if !_this._hasChanges() {
+		_this.app.UI.Flash().Warn("No pending changes")
+		return
+	}
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Discard Changes",
+		fmt.Sprintf("Discard %d pending changes?", _this._pendingCount()),
+		func(force bool) {
+			_this._clearChanges()
+			row, _ := _this.dataTable.GetSelection()
+			_this._render(row)
+		},
+		func() {
+			_this.focusTable()
+		})
+}
+
+// _rowChanges 将未提交的修改转换为驱动的行修改，先删除、再更新、最后新增，避免主键冲突
+func (_this *DatabaseTableComponent) _rowChanges() ([]database_drivers.RowChange, error) {
+	value := func(cell string) *string {
+		switch cell {
+		case dbNullCell:
+			return nil
+		case dbEmptyCell:
+			cell = ""
+		}
+		return &cell
+	}
+	key := func(row int) ([]database_drivers.ColumnValue, error) {
+		key := make([]database_drivers.ColumnValue, 0, len(_this.primaryKeys))
+		for _, pk := range _this.primaryKeys {
+			col := slices.Index(_this.columns, pk)
+			if col < 0 {
+				return nil, fmt.Errorf("primary key %s not found in the result", pk)
+			}
+			key = append(key, database_drivers.ColumnValue{Column: pk, Value: value(_this.records[row][col])})
+		}
+		return key, nil
+	}
+	sortedRows := func(rows []int) []int {
+		sort.Ints(rows)
+		return rows
+	}
+
+	var changes []database_drivers.RowChange
+	var deleted []int
+	for row := range _this.deletes {
+		deleted = append(deleted, row)
+	}
+	for _, row := range sortedRows(deleted) {
+		k, err := key(row)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, database_drivers.RowChange{Kind: database_drivers.RowDelete, Key: k})
+	}
+
+	var edited []int
+	for row := range _this.edits {
+		if !_this.deletes[row] {
+			edited = append(edited, row)
+		}
+	}
+	for _, row := range sortedRows(edited) {
+		k, err := key(row)
+		if err != nil {
+			return nil, err
+		}
+		change := database_drivers.RowChange{Kind: database_drivers.RowUpdate, Key: k}
+		for col := range _this.columns {
+			if cell, ok := _this.edits[row][col]; ok {
+				change.Values = append(change.Values, database_drivers.ColumnValue{Column: _this.columns[col], Value: value(cell)})
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for _, row := range _this.inserts {
+		change := database_drivers.RowChange{Kind: database_drivers.RowInsert}
+		for col, cell := range row {
+			if cell != dbDefaultCell {
+				change.Values = append(change.Values, database_drivers.ColumnValue{Column: _this.columns[col], Value: value(cell)})
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// commitChanges 预览修改对应的语句，确认后在一个事务中提交
+func (_this *DatabaseTableComponent) commitChanges() {
+	if !_this._hasChanges() {
+		_this.app.UI.Flash().Warn("No pending changes")
+		return
+	}
+	changes, err := _this._rowChanges()
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	statements, err := _this.dbConn.BuildStatements(_this.dbName, _this.tableName, changes)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	previews := make([]string, 0, len(statements))
+	for _, s := range statements {
+		previews = append(previews, s.Preview)
+	}
+	dialog.ShowDatabaseChanges(&config.Dialog{}, _this.app.Content.Pages, &dialog.DatabaseChangesOpts{
+		Statements: previews,
+		Ack: func() {
+			_this._executeStatements(statements)
+		},
+		Cancel: func() {
+			_this.focusTable()
+		},
+	})
+}
+
+// _executeStatements 异步执行语句，成功后重新加载当前页，失败时保留修改便于调整后重试
+func (_this *DatabaseTableComponent) _executeStatements(statements []database_drivers.Statement) {
+	loading := dialog.ShowLoadingDialog(
+		_this.app.Content.Pages,
+		fmt.Sprintf("⏳ Committing %d statements...", len(statements)),
+		_this.app.UI.ForceDraw,
+	)
+	go func() {
+		err := _this.dbConn.ExecuteStatements(_this.dbName, statements)
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			defer _this.focusTable()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				return
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("%d statements committed to %s", len(statements), _this.tableName))
+			_this._clearChanges()
+			records, err := _this._fetchPage()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				_this._render(1)
+				return
+			}
+			_this.SetTableData(records)
+		})
+	}()
+}
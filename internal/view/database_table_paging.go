@@ -0,0 +1,159 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/28 10:00
+ */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// _fetchPage 按当前的过滤条件、排序和页码查询数据，并记录总行数
+func (_this *DatabaseTableComponent) _fetchPage() ([][]string, error) {
+	records, total, err := _this.dbConn.GetRecords(
+		_this.dbName,
+		_this.tableName,
+		_this.where,
+		database_drivers.OrderBy(_this.dbCfg.Provider, _this.sortColumn, _this.sortDesc),
+		_this.page*_this.pageSize,
+		_this.pageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	_this.total = total
+	if len(records) > 0 {
+		_this.columns = records[0]
+	}
+	return records, nil
+}
+
+// _gotoPage 跳转到指定页，查询失败时保持在原来的页
+func (_this *DatabaseTableComponent) _gotoPage(page int) {
+	if !_this._guardChanges() {
+		return
+	}
+	prev := _this.page
+	_this.page = page
+	records, err := _this._fetchPage()
+	if err != nil {
+		_this.page = prev
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	_this.SetTableData(records)
+}
+
+// nextPage 下一页
+func (_this *DatabaseTableComponent) nextPage() {
+	if _this.page+1 >= _this._pageCount() {
+		_this.app.UI.Flash().Warn("Already on the last page")
+		return
+	}
+	_this._gotoPage(_this.page + 1)
+}
+
+// prevPage 上一页
+func (_this *DatabaseTableComponent) prevPage() {
+	if _this.page == 0 {
+		_this.app.UI.Flash().Warn("Already on the first page")
+		return
+	}
+	_this._gotoPage(_this.page - 1)
+}
+
+// jumpPage 输入页码跳转
+func (_this *DatabaseTableComponent) jumpPage() {
+	pageCount := _this._pageCount()
+	dialog.ShowDatabaseJumpPage(&config.Dialog{}, _this.app.Content.Pages, &dialog.DatabaseJumpPageOpts{
+		Page:      _this.page + 1,
+		PageCount: pageCount,
+		Ack: func(page int) bool {
+			if page < 1 || page > pageCount {
+				_this.app.UI.Flash().Err(fmt.Errorf("page must be between 1 and %d", pageCount))
+				return false
+			}
+			_this._gotoPage(page - 1)
+			return true
+		},
+		Cancel: func() {
+			_this.focusTable()
+		},
+	})
+}
+
+// toggleSort 按选中单元格所在的列排序，依次切换升序、降序和不排序
+func (_this *DatabaseTableComponent) toggleSort() {
+	_, column := _this.dataTable.GetSelection()
+	_this._sortBy(column)
+}
+
+// _sortBy 切换指定列的排序方式，排序变化后回到第一页
+func (_this *DatabaseTableComponent) _sortBy(column int) {
+	if column < 0 || column >= len(_this.columns) || !_this._guardChanges() {
+		return
+	}
+	name := _this.columns[column]
+	switch {
+	case _this.sortColumn != name:
+		_this.sortColumn, _this.sortDesc = name, false
+	case !_this.sortDesc:
+		_this.sortDesc = true
+	default:
+		_this.sortColumn, _this.sortDesc = "", false
+	}
+	_this._gotoPage(0)
+	_this.dataTable.Select(1, column)
+}
+
+// _decorateHeader 表头标记排序方向，点击表头也可以排序
+func (_this *DatabaseTableComponent) _decorateHeader() {
+	for col := 0; col < _this.dataTable.GetColumnCount(); col++ {
+		cell := _this.dataTable.GetCell(0, col)
+		if cell == nil {
+			continue
+		}
+		if col < len(_this.columns) && _this.columns[col] == _this.sortColumn {
+			arrow := " ▲"
+			if _this.sortDesc {
+				arrow = " ▼"
+			}
+			cell.SetText(cell.Text + arrow)
+		}
+		cell.SetClickedFunc(func() bool {
+			_this._sortBy(col)
+			return true
+		})
+	}
+}
+
+// _updateFooter 展示当前页的行范围、页码和排序
+func (_this *DatabaseTableComponent) _updateFooter() {
+	rows := max(len(_this.records)-1, 0)
+	text := "[gray]No rows"
+	if rows > 0 {
+		from := _this.page*_this.pageSize + 1
+		text = fmt.Sprintf("[white]Rows %d-%d of %d", from, from+rows-1, _this.total)
+	}
+	text += fmt.Sprintf("  [white]Page %d/%d", _this.page+1, _this._pageCount())
+	if _this.sortColumn != "" {
+		direction := "ASC"
+		if _this.sortDesc {
+			direction = "DESC"
+		}
+		text += fmt.Sprintf("  [yellow]Sort %s %s", _this.sortColumn, direction)
+	}
+	if _this._hasChanges() {
+		text += fmt.Sprintf("  [orange]%d pending changes", _this._pendingCount())
+	}
+	_this.footer.SetText(text)
+}
+
+func (_this *DatabaseTableComponent) _pageCount() int {
+	return database_drivers.PageCount(_this.total, _this.pageSize)
+}
@@ -0,0 +1,168 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/29 14:00
+ */
+
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/database_drivers"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// 表格组件的标签页
+const (
+	dbTabData = iota
+	dbTabStructure
+)
+
+var dbTabNames = []string{"Data", "Structure"}
+
+// _initTabs 初始化标签栏、数据标签页和表结构标签页
+func (_this *DatabaseTableComponent) _initTabs() {
+	_this.tabBarUI = tview.NewTextView()
+	_this.tabBarUI.SetDynamicColors(true).
+		SetRegions(true).
+		SetBorderPadding(0, 0, 1, 1)
+	var b strings.Builder
+	for i, name := range dbTabNames {
+		_, _ = fmt.Fprintf(&b, `["%d"] %d %s [""]  `, i, i+1, name)
+	}
+	_this.tabBarUI.SetText(b.String())
+	_this.tabBarUI.Highlight(fmt.Sprint(dbTabData))
+
+	_this.dataFlex = tview.NewFlex()
+	_this.dataFlex.SetDirection(tview.FlexRow)
+	_this.structureViewUI = tview.NewTextView()
+	_this.structureViewUI.SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false).
+		SetBorderPadding(1, 1, 2, 2)
+
+	_this.tabPagesUI = tview.NewPages()
+	_this.tabPagesUI.AddPage(dbTabNames[dbTabData], _this.dataFlex, true, true)
+	_this.tabPagesUI.AddPage(dbTabNames[dbTabStructure], _this.structureViewUI, true, false)
+	_this.AddItem(_this.tabBarUI, 1, 0, false)
+	_this.AddItem(_this.tabPagesUI, 0, 1, true)
+}
+
+// switchTab 切换标签页，表结构每次切换时重新加载
+func (_this *DatabaseTableComponent) switchTab(tab int) {
+	_this.currentTab = tab
+	_this.tabBarUI.Highlight(fmt.Sprint(tab))
+	_this.tabPagesUI.SwitchToPage(dbTabNames[tab])
+	if tab == dbTabStructure {
+		_this._refreshStructure()
+	}
+	_this.focusCurrent()
+}
+
+// focusCurrent 将焦点设置到当前的标签页
+func (_this *DatabaseTableComponent) focusCurrent() {
+	if _this.currentTab == dbTabStructure {
+		_this.app.UI.SetFocus(_this.structureViewUI)
+		return
+	}
+	_this.focusTable()
+}
+
+// _refreshStructure 加载并展示表结构
+func (_this *DatabaseTableComponent) _refreshStructure() {
+	s, err := _this.dbConn.GetTableStructure(_this.dbName, _this.tableName)
+	if err != nil {
+		_this.structureViewUI.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+		return
+	}
+	_this.structureViewUI.SetText(formatTableStructure(s))
+	_this.structureViewUI.ScrollToBeginning()
+}
+
+// formatTableStructure 表结构的展示文本，依次为列、索引、外键、触发器和建表语句
+func formatTableStructure(s *database_drivers.TableStructure) string {
+	var b strings.Builder
+
+	columns := [][]string{{"Name", "Type", "Nullable", "Default", "Key", "Extra", "Comment"}}
+	for _, c := range s.Columns {
+		def := "NULL"
+		if c.Default != nil {
+			def = *c.Default
+		}
+		columns = append(columns, []string{c.Name, c.Type, yesNo(c.Nullable), def, c.Key, c.Extra, c.Comment})
+	}
+	writeStructureSection(&b, "Columns", columns)
+
+	indexes := [][]string{{"Name", "Columns", "Unique", "Primary", "Type"}}
+	for _, i := range s.Indexes {
+		indexes = append(indexes, []string{i.Name, strings.Join(i.Columns, ", "), yesNo(i.Unique), yesNo(i.Primary), i.Type})
+	}
+	writeStructureSection(&b, "Indexes", indexes)
+
+	fks := [][]string{{"Name", "Columns", "References", "On Update", "On Delete"}}
+	for _, fk := range s.ForeignKeys {
+		fks = append(fks, []string{
+			fk.Name,
+			strings.Join(fk.Columns, ", "),
+			fmt.Sprintf("%s(%s)", fk.RefTable, strings.Join(fk.RefColumns, ", ")),
+			fk.OnUpdate,
+			fk.OnDelete,
+		})
+	}
+	writeStructureSection(&b, "Foreign Keys", fks)
+
+	triggers := [][]string{{"Name", "Timing", "Event", "Statement"}}
+	for _, t := range s.Triggers {
+		// 语句可能有多行，合并为一行展示，完整内容见建表语句
+		triggers = append(triggers, []string{t.Name, t.Timing, t.Event, strings.Join(strings.Fields(t.Statement), " ")})
+	}
+	writeStructureSection(&b, "Triggers", triggers)
+
+	b.WriteString("[aqua::b]DDL[-::-]\n")
+	if s.DDL == "" {
+		b.WriteString("[gray]None[-]\n")
+	} else {
+		b.WriteString(tview.Escape(s.DDL))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// writeStructureSection 按列对齐输出一节，第一行为表头
+func writeStructureSection(b *strings.Builder, title string, rows [][]string) {
+	_, _ = fmt.Fprintf(b, "[aqua::b]%s (%d)[-::-]\n", title, len(rows)-1)
+	if len(rows) == 1 {
+		b.WriteString("[gray]None[-]\n\n")
+		return
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	// 单元格中的制表符和换行会破坏对齐
+	replacer := strings.NewReplacer("\t", " ", "\r", "", "\n", " ")
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, replacer.Replace(cell))
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+	for i, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		line = tview.Escape(strings.TrimRight(line, " "))
+		if i == 0 {
+			line = "[yellow]" + line + "[-]"
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
@@ -31,8 +31,8 @@ func (_this *DatabaseTableView) selfFocus() {
 	if comp == nil {
 		_this.app.UI.SetFocus(_this)
 	} else {
-		// 设置当前焦点为表格组件
-		_this.app.UI.SetFocus(comp.dataTable)
+		// 设置当前焦点为表格组件当前的标签页
+		comp.focusCurrent()
 	}
 }
 
@@ -16,24 +16,62 @@ import (
 	"github.com/liangzhaoliang95/lxz/internal/config"
 	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
 	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/model"
 	"github.com/liangzhaoliang95/lxz/internal/ui"
 	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
 	"github.com/liangzhaoliang95/tview"
+	"github.com/moby/moby/api/types/container"
 )
 
 type DockerBrowser struct {
 	*BaseFlex
 	app                 *App
 	containerTableUI    *tview.Table
-	selectedContainerID string // 当前选中的容器ID
-	selectContainerName string // 当前选中的容器名称
+	selectedContainerID string            // 当前选中的容器ID
+	selectContainerName string            // 当前选中的容器名称
+	selectedProject     string            // 当前选中的 compose 项目，选中容器行时为空
+	collapsed           map[string]bool   // 已折叠的 compose 项目
+	marked              map[string]string // 已标记的容器ID -> 容器名称，批量操作时使用
+	stats               *dockerStatsCollector
+	events              *docker_drivers.EventHistory // 最近的引擎事件
+	cancelFn            context.CancelFunc           // 停止资源统计的定时刷新
+	eventsCancelFn      context.CancelFunc           // 停止订阅引擎事件
 }
 
+// dockerRowRef 容器列表每一行的引用，compose 项目分组行的 id 为空
+type dockerRowRef struct {
+	project string   // 所属 compose 项目
+	id      string   // 容器ID
+	name    string   // 容器名称
+	members []string // 分组行下运行中容器的短ID，用于汇总资源统计
+}
+
+// 资源统计列的起始列号，端口列在统计列之后
+const (
+	dockerStatsColumn = 6
+	dockerPortColumn  = dockerStatsColumn + 4
+)
+
 func (_this *DockerBrowser) bindKeys() {
 	_this.Actions().Bulk(ui.KeyMap{
 		ui.KeyF:        ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
 		ui.KeyI:        ui.NewKeyAction("Detail Info", _this.ShowDetail, true),
 		ui.KeyS:        ui.NewKeyAction("Shell", _this.ShellExec, true),
+		ui.KeyShiftS:   ui.NewKeyAction("Stats", _this.showStats, true),
+		ui.KeyShiftI:   ui.NewKeyAction("Images", _this.showImages, true),
+		ui.KeyShiftN:   ui.NewKeyAction("Networks", _this.showNetworks, true),
+		ui.KeyShiftV:   ui.NewKeyAction("Volumes", _this.showVolumes, true),
+		ui.KeyShiftH:   ui.NewKeyAction("Switch Host", _this.switchHost, true),
+		ui.KeySpace:    ui.NewKeyAction("Collapse/Expand", _this.toggleProject, true),
+		ui.KeyT:        ui.NewKeyAction("Transfer", _this.transferFiles, true),
+		ui.KeyE:        ui.NewKeyAction("Events", _this.showEvents, true),
+		ui.KeyM:        ui.NewKeyAction("Mark", _this.toggleMark, true),
+		ui.KeyShiftM:   ui.NewKeyAction("Unmark All", _this.clearMarks, true),
+		ui.KeyU:        ui.NewKeyAction("Start", _this.startContainers, true),
+		ui.KeyP:        ui.NewKeyAction("Pause/Unpause", _this.pauseContainers, true),
+		ui.KeyK:        ui.NewKeyAction("Kill", _this.killContainers, true),
+		ui.KeyR:        ui.NewKeyAction("Rename", _this.renameContainer, true),
+		ui.KeyShiftR:   ui.NewKeyAction("Run", _this.runContainer, true),
 		tcell.KeyEnter: ui.NewKeyAction("Logs", _this.EmptyKeyEvent, true),
 		tcell.KeyCtrlR: ui.NewKeyAction("Restart", _this.restartContainer, true),
 		tcell.KeyCtrlD: ui.NewKeyAction("Stop Or Delete", _this.stopDeleteContainer, true),
@@ -77,8 +115,169 @@ func (_this *DockerBrowser) ShowDetail(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+// showStats 查看容器资源使用的趋势
+func (_this *DockerBrowser) showStats(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedContainerID == "" {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	history := _this.stats.History(_this.selectedContainerID)
+	page := NewDockerStatsView(_this.app, _this.selectedContainerID, _this.selectContainerName, history)
+	if err := _this.app.inject(page, false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker stats view: %w", err))
+	}
+	return nil
+}
+
+// showImages 打开镜像列表
+func (_this *DockerBrowser) showImages(evt *tcell.EventKey) *tcell.EventKey {
+	if err := _this.app.inject(NewDockerImageBrowser(_this.app), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker image browser: %w", err))
+	}
+	return nil
+}
+
+// showNetworks 打开网络列表
+func (_this *DockerBrowser) showNetworks(evt *tcell.EventKey) *tcell.EventKey {
+	if err := _this.app.inject(NewDockerNetworkBrowser(_this.app), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker network browser: %w", err))
+	}
+	return nil
+}
+
+// showVolumes 打开数据卷列表
+func (_this *DockerBrowser) showVolumes(evt *tcell.EventKey) *tcell.EventKey {
+	if err := _this.app.inject(NewDockerVolumeBrowser(_this.app), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker volume browser: %w", err))
+	}
+	return nil
+}
+
+// runContainer 输入镜像引用运行新容器
+func (_this *DockerBrowser) runContainer(evt *tcell.EventKey) *tcell.EventKey {
+	showDockerRun(_this.app, "", _this.containerTableUI, func(string) {
+		_this._refreshData()
+	})
+	return nil
+}
+
+// showEvents 查看最近的引擎事件
+func (_this *DockerBrowser) showEvents(evt *tcell.EventKey) *tcell.EventKey {
+	if err := _this.app.inject(NewDockerEventsView(_this.app, _this.events), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker events view: %w", err))
+	}
+	return nil
+}
+
+// toggleProject 折叠或展开选中行所属的 compose 项目
+func (_this *DockerBrowser) toggleProject(evt *tcell.EventKey) *tcell.EventKey {
+	ref := _this._selectedRef()
+	if ref == nil || ref.project == "" {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a compose project first"))
+		return nil
+	}
+	_this.collapsed[ref.project] = !_this.collapsed[ref.project]
+	_this._refreshData()
+	// 折叠后选中行可能已不存在，定位到项目分组行
+	for row := 1; row < _this.containerTableUI.GetRowCount(); row++ {
+		if r, ok := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef); ok &&
+			r.id == "" && r.project == ref.project {
+			_this.containerTableUI.Select(row, 0)
+			break
+		}
+	}
+	return nil
+}
+
+// restartProject 重启 compose 项目下的所有容器
+func (_this *DockerBrowser) restartProject() {
+	project := _this.selectedProject
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to restart the project?",
+		project,
+		func(force bool) {
+			loading := dialog.ShowLoadingDialog(
+				appViewInstance.Content.Pages,
+				"",
+				appUiInstance.ForceDraw,
+			)
+			var timeout *int
+			if force {
+				timeout = helper.Ptr(0)
+			}
+			if err := docker_drivers.RestartComposeProject(project, timeout); err != nil {
+				_this.app.UI.Flash().Err(err)
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Project:%s restarted successfully", project))
+			}
+			_this._refreshData()
+			loading.Hide()
+		},
+		func() {
+
+		})
+}
+
+// stopDeleteProject 项目有运行中的容器时全部停止，否则删除项目的容器和网络
+func (_this *DockerBrowser) stopDeleteProject() {
+	project := _this.selectedProject
+	containers, err := docker_drivers.ListComposeContainers(project)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	operation := "delete"
+	for _, ctr := range containers {
+		if ctr.State == "running" {
+			operation = "stop"
+			break
+		}
+	}
+
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		fmt.Sprintf("Are you sure you want to %s the project?", operation),
+		fmt.Sprintf("%s (%d containers)", project, len(containers)),
+		func(force bool) {
+			loading := dialog.ShowLoadingDialog(
+				appViewInstance.Content.Pages,
+				"",
+				appUiInstance.ForceDraw,
+			)
+			var err error
+			if operation == "delete" {
+				err = docker_drivers.RemoveComposeProject(project, force)
+			} else {
+				var timeout *int
+				if force {
+					timeout = helper.Ptr(0)
+				}
+				err = docker_drivers.StopComposeProject(project, timeout)
+			}
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Project:%s %s successfully", project, operation))
+			}
+			_this._refreshData()
+			loading.Hide()
+		},
+		func() {
+
+		})
+}
+
 // restartContainer
 func (_this *DockerBrowser) restartContainer(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.isMarking() {
+		_this._restartMarked()
+		return nil
+	}
+	if _this.selectedProject != "" {
+		_this.restartProject()
+		return nil
+	}
 	dialog.ShowConfirm(&config.Dialog{},
 		_this.app.Content.Pages,
 		"Are you sure you want to restart the container?",
@@ -111,6 +310,14 @@ func (_this *DockerBrowser) restartContainer(evt *tcell.EventKey) *tcell.EventKe
 
 // stopDeleteContainer
 func (_this *DockerBrowser) stopDeleteContainer(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.isMarking() {
+		_this._stopDeleteMarked()
+		return nil
+	}
+	if _this.selectedProject != "" {
+		_this.stopDeleteProject()
+		return nil
+	}
 	detail, err := docker_drivers.InspectContainer(_this.selectedContainerID)
 	if err != nil {
 		_this.app.UI.Flash().
@@ -193,7 +400,14 @@ func (_this *DockerBrowser) _initHeader() {
 		SetAlign(tview.AlignLeft).
 		SetExpansion(1).
 		SetSelectable(false))
-	_this.containerTableUI.SetCell(0, 6, tview.NewTableCell("Port").
+	for i, header := range []string{"CPU %", "Mem Usage / Limit", "Net I/O", "Block I/O"} {
+		_this.containerTableUI.SetCell(0, dockerStatsColumn+i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1).
+			SetSelectable(false))
+	}
+	_this.containerTableUI.SetCell(0, dockerPortColumn, tview.NewTableCell("Port").
 		SetTextColor(tcell.ColorYellow).
 		SetAlign(tview.AlignLeft).
 		SetExpansion(1).
@@ -206,71 +420,251 @@ func (_this *DockerBrowser) _refreshData() {
 		_this.app.UI.Flash().Err(err)
 		return
 	}
-	// 填充容器数据
-	for i, ctr := range ctrList {
-		if i == 0 {
-			_this.selectedContainerID = ctr.ID
-			_this.selectContainerName = ctr.Names[0][1:] // 默认选中
+	// 容器数量和折叠状态都会改变行数，清空后重新填充
+	_this.containerTableUI.Clear()
+	_this._initHeader()
+
+	// 已不存在的容器取消标记
+	exists := make(map[string]bool, len(ctrList))
+	for _, ctr := range ctrList {
+		exists[ctr.ID] = true
+	}
+	for id := range _this.marked {
+		if !exists[id] {
+			delete(_this.marked, id)
 		}
-		_this.containerTableUI.SetCell(i+1, 0, tview.NewTableCell(ctr.ID[:12]).
-			SetReference(ctr.ID).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		_this.containerTableUI.SetCell(i+1, 1, tview.NewTableCell(ctr.Names[0][1:]).
-			SetReference(ctr.Names[0]).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		_this.containerTableUI.SetCell(i+1, 2, tview.NewTableCell(ctr.Image).
-			SetReference(ctr.Status).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		_this.containerTableUI.SetCell(i+1, 3, tview.NewTableCell(helper.TimeFormat(ctr.Created)).
-			SetReference(ctr.Status).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		_this.containerTableUI.SetCell(i+1, 4, tview.NewTableCell(ctr.Status).
-			SetReference(ctr.Status).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		_this.containerTableUI.SetCell(i+1, 5, tview.NewTableCell(ctr.State).
-			SetReference(ctr.Status).
-			SetTextColor(tcell.ColorWhite).
+	}
+
+	projects, standalone := docker_drivers.GroupComposeProjects(ctrList)
+	running := make([]string, 0, len(ctrList))
+	row := 1
+	for _, project := range projects {
+		members := make([]string, 0, len(project.Containers))
+		for _, ctr := range project.Containers {
+			if ctr.State == "running" {
+				members = append(members, ctr.ID[:12])
+			}
+		}
+		running = append(running, members...)
+		_this._setProjectRow(row, project, members)
+		row++
+		if _this.collapsed[project.Name] {
+			continue
+		}
+		for _, ctr := range project.Containers {
+			_this._setContainerRow(row, ctr, project.Name)
+			row++
+		}
+	}
+	for _, ctr := range standalone {
+		if ctr.State == "running" {
+			running = append(running, ctr.ID[:12])
+		}
+		_this._setContainerRow(row, ctr, "")
+		row++
+	}
+	_this.stats.Track(running)
+	_this._refreshStats()
+
+	// 保持原选中行，超出范围时选中最后一行
+	selected, _ := _this.containerTableUI.GetSelection()
+	selected = min(max(selected, 1), row-1)
+	_this.containerTableUI.Select(selected, 0)
+	_this._syncSelection(selected)
+}
+
+// _setProjectRow 填充 compose 项目分组行
+func (_this *DockerBrowser) _setProjectRow(row int, project *docker_drivers.ComposeProject, members []string) {
+	icon := "▼"
+	if _this.collapsed[project.Name] {
+		icon = "▶"
+	}
+	state := "exited"
+	if n := project.Running(); n == len(project.Containers) {
+		state = "running"
+	} else if n > 0 {
+		state = "partial"
+	}
+	columns := []string{
+		fmt.Sprintf("%s %s", icon, project.Name),
+		fmt.Sprintf("%d containers", len(project.Containers)),
+		"",
+		"",
+		fmt.Sprintf("%d/%d running", project.Running(), len(project.Containers)),
+		state,
+	}
+	for i, text := range columns {
+		cell := tview.NewTableCell(tview.Escape(text)).
+			SetTextColor(tcell.ColorAqua).
 			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
-		ports := ""
-		if len(ctr.Ports) > 0 {
-			for _, port := range ctr.Ports {
-				if ports != "" {
-					ports += ", "
-				}
-				if port.PublicPort > 0 {
-					ports += fmt.Sprintf(
-						"%s:%d -> %d/%s",
-						port.IP,
-						port.PublicPort,
-						port.PrivatePort,
-						port.Type,
-					)
-				} else {
-					ports += fmt.Sprintf("%d/%s", port.PrivatePort, port.Type)
-				}
+			SetExpansion(1)
+		if i == 0 {
+			cell.SetReference(&dockerRowRef{project: project.Name, members: members})
+		}
+		_this.containerTableUI.SetCell(row, i, cell)
+	}
+	_this.containerTableUI.SetCell(row, dockerPortColumn, tview.NewTableCell("").SetExpansion(1))
+}
+
+// _setContainerRow 填充容器行，属于 compose 项目的容器缩进显示在项目下
+func (_this *DockerBrowser) _setContainerRow(row int, ctr *container.Summary, project string) {
+	name := ctr.Names[0][1:]
+	displayName := name
+	if project != "" {
+		displayName = "  " + name
+	}
+	fg := tcell.ColorWhite
+	if _, ok := _this.marked[ctr.ID]; ok {
+		fg = dockerMarkedColor
+	}
+	_this.containerTableUI.SetCell(row, 0, tview.NewTableCell(ctr.ID[:12]).
+		SetReference(&dockerRowRef{project: project, id: ctr.ID, name: name}).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	_this.containerTableUI.SetCell(row, 1, tview.NewTableCell(displayName).
+		SetReference(ctr.Names[0]).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	_this.containerTableUI.SetCell(row, 2, tview.NewTableCell(ctr.Image).
+		SetReference(ctr.Status).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	_this.containerTableUI.SetCell(row, 3, tview.NewTableCell(helper.TimeFormat(ctr.Created)).
+		SetReference(ctr.Status).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	_this.containerTableUI.SetCell(row, 4, tview.NewTableCell(ctr.Status).
+		SetReference(ctr.Status).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	_this.containerTableUI.SetCell(row, 5, tview.NewTableCell(ctr.State).
+		SetReference(ctr.Status).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+	ports := ""
+	if len(ctr.Ports) > 0 {
+		for _, port := range ctr.Ports {
+			if ports != "" {
+				ports += ", "
+			}
+			if port.PublicPort > 0 {
+				ports += fmt.Sprintf(
+					"%s:%d -> %d/%s",
+					port.IP,
+					port.PublicPort,
+					port.PrivatePort,
+					port.Type,
+				)
+			} else {
+				ports += fmt.Sprintf("%d/%s", port.PrivatePort, port.Type)
 			}
+		}
+	} else {
+		ports = "None"
+	}
+	_this.containerTableUI.SetCell(row, dockerPortColumn, tview.NewTableCell(ports).
+		SetReference(ctr.Status).
+		SetTextColor(fg).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1))
+}
+
+// _selectedRef 当前选中行的引用
+func (_this *DockerBrowser) _selectedRef() *dockerRowRef {
+	row, _ := _this.containerTableUI.GetSelection()
+	if row < 1 || row >= _this.containerTableUI.GetRowCount() {
+		return nil
+	}
+	ref, _ := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+	return ref
+}
+
+// _syncSelection 根据选中行更新选中的容器或 compose 项目
+func (_this *DockerBrowser) _syncSelection(row int) {
+	_this.selectedContainerID, _this.selectContainerName, _this.selectedProject = "", "", ""
+	if row < 1 || row >= _this.containerTableUI.GetRowCount() {
+		return
+	}
+	ref, ok := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+	if !ok {
+		return
+	}
+	if ref.id == "" {
+		_this.selectedProject = ref.project
+		return
+	}
+	_this.selectedContainerID = ref.id
+	_this.selectContainerName = ref.name
+}
+
+// _refreshStats 刷新表格中的资源统计列，项目分组行显示其下容器的汇总
+func (_this *DockerBrowser) _refreshStats() {
+	for row := 1; row < _this.containerTableUI.GetRowCount(); row++ {
+		ref, ok := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+		if !ok {
+			continue
+		}
+		var stats *docker_drivers.ContainerStatsData
+		if ref.id == "" {
+			stats = _this._sumStats(ref.members)
 		} else {
-			ports = "None"
+			stats = _this.stats.Latest(shortDockerID(ref.id))
+		}
+		columns := []string{formatCPU(stats), formatMemory(stats), formatNetIO(stats), formatBlockIO(stats)}
+		for i, text := range columns {
+			_this.containerTableUI.SetCell(row, dockerStatsColumn+i, tview.NewTableCell(text).
+				SetTextColor(_this.containerTableUI.GetCell(row, 0).Color).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(1))
 		}
-		_this.containerTableUI.SetCell(i+1, 6, tview.NewTableCell(ports).
-			SetReference(ctr.Status).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1))
 	}
 }
 
+// _sumStats 汇总多个容器最近一次的资源统计，没有任何采样时返回 nil
+func (_this *DockerBrowser) _sumStats(containerIDs []string) *docker_drivers.ContainerStatsData {
+	var sum *docker_drivers.ContainerStatsData
+	for _, id := range containerIDs {
+		stats := _this.stats.Latest(id)
+		if stats == nil {
+			continue
+		}
+		if sum == nil {
+			sum = &docker_drivers.ContainerStatsData{}
+		}
+		sum.CPUPercent += stats.CPUPercent
+		sum.MemoryUsage += stats.MemoryUsage
+		sum.NetRx += stats.NetRx
+		sum.NetTx += stats.NetTx
+		sum.BlockRead += stats.BlockRead
+		sum.BlockWrite += stats.BlockWrite
+	}
+	return sum
+}
+
+// _startStatsRefresh 按 LXZ.RefreshRate 定时刷新资源统计列
+func (_this *DockerBrowser) _startStatsRefresh() {
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(dockerRefreshInterval(_this.app))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_this.app.UI.QueueUpdateDraw(_this._refreshStats)
+			}
+		}
+	}()
+}
+
 func (_this *DockerBrowser) Init(ctx context.Context) error {
 	_this.bindKeys()
 	_this.SetInputCapture(_this.Keyboard)
@@ -281,49 +675,63 @@ func (_this *DockerBrowser) Init(ctx context.Context) error {
 	_this.containerTableUI.SetBorderPadding(1, 1, 2, 2)
 	_this.containerTableUI.SetSelectable(true, false)
 	_this.containerTableUI.Select(1, 0)
-	// 配置回车函数
+	// 配置回车函数，项目分组行打开合并日志
 	_this.containerTableUI.SetSelectedFunc(func(row, column int) {
 		slog.Info("Selected connection", "row", row, "col", column)
-		// 获取选中的连接信息
-		if row < 1 || row >= _this.containerTableUI.GetRowCount() {
+		_this._syncSelection(row)
+		var page model.Component
+		switch {
+		case _this.selectedProject != "":
+			page = NewDockerComposeLogsPage(_this.app, _this.selectedProject)
+		case _this.selectedContainerID != "":
+			page = NewDockerLogsPage(_this.app, shortDockerID(_this.selectedContainerID), _this.selectContainerName)
+		default:
 			slog.Warn("Selected row is out of range", "row", row)
 			return
 		}
-		connName := _this.containerTableUI.GetCell(row, 1).Text
-		// 渲染日志页面
-		connID := _this.containerTableUI.GetCell(row, 0).Text
-		if err := _this.app.inject(NewDockerLogsPage(_this.app, connID, connName), false); err != nil {
+		if err := _this.app.inject(page, false); err != nil {
 			_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker logs page: %w", err))
 		}
 	})
 	// 设置表格的选择模式
 	_this.containerTableUI.SetSelectionChangedFunc(func(row, column int) {
 		slog.Info("Selection changed", "row", row, "col", column)
-		if row < 1 || row >= _this.containerTableUI.GetRowCount() {
-			slog.Warn("Selection changed row is out of range", "row", row)
-			return
-		}
-		_this.selectedContainerID = _this.containerTableUI.GetCell(row, 0).Text
-		_this.selectContainerName = _this.containerTableUI.GetCell(row, 1).Text
+		_this._syncSelection(row)
 	})
 	_this._initHeader()
 
 	_this.AddItem(_this.containerTableUI, 0, 1, true)
+	_this._updateTitle()
 
 	return nil
 }
 
 func (_this *DockerBrowser) Start() {
 	// ✅ 设置默认边框颜色 + 焦点 + 强制刷新
+	_this.Stop()
+	// 从最后一个已记录的事件继续订阅，首次进入时只接收新事件
+	since := _this.events.Last()
+	if since.IsZero() {
+		since = time.Now()
+	}
 	_this._refreshData()
+	_this._startStatsRefresh()
+	_this._startEventsWatch(since)
 
 	_this.app.UI.SetFocus(_this.containerTableUI)
 }
 
 func (_this *DockerBrowser) Stop() {
-	// if _this.stopDebounceCh != nil {
-	//	close(_this.stopDebounceCh) // 停止防抖协程
-	//}
+	// 页面被覆盖或关闭时停止资源统计和事件订阅，可重复调用
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
+	if _this.eventsCancelFn != nil {
+		_this.eventsCancelFn()
+		_this.eventsCancelFn = nil
+	}
+	_this.stats.Stop()
 }
 
 // --- HELPER FUNCTIONS ---
@@ -337,18 +745,9 @@ func (_this *DockerBrowser) containerShellIn() error {
 }
 
 func (_this *DockerBrowser) shellIn() {
-	args := make([]string, 0, 15)
-
-	args = append(args, "exec", "-it", _this.selectedContainerID)
-	args = append(args, "sh", "-c", shellCheck)
-
-	slog.Info("Shell args", "args", args)
+	slog.Info("Shell in container", "container", _this.selectedContainerID)
 	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
-	err := runDockerExec(_this.app, &shellOpts{
-		clear:  true,
-		banner: c.Sprintf(bannerFmt, ""),
-		args:   args},
-	)
+	err := runNativeExec(_this.app, _this.selectedContainerID, c.Sprintf(bannerFmt, _this.selectContainerName))
 	if err != nil {
 		_this.app.UI.Flash().Errf("Shell exec failed: %s", err)
 	}
@@ -357,8 +756,12 @@ func (_this *DockerBrowser) shellIn() {
 func NewDockerBrowser(app *App) *DockerBrowser {
 	var name = "Docker Browser"
 	f := &DockerBrowser{
-		BaseFlex: NewBaseFlex(name),
-		app:      app,
+		BaseFlex:  NewBaseFlex(name),
+		app:       app,
+		stats:     newDockerStatsCollector(),
+		events:    docker_drivers.NewEventHistory(dockerEventHistorySize),
+		collapsed: make(map[string]bool),
+		marked:    make(map[string]string),
 	}
 	f.SetIdentifier(ui.DOCKER_BROWSER_ID)
 	return f
@@ -0,0 +1,105 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/24 11:00
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// composeLogColors 不同服务的日志前缀颜色
+var composeLogColors = []string{"aqua", "green", "yellow", "fuchsia", "blue", "orange", "lime", "purple"}
+
+// DockerComposeLogsPage compose 项目所有服务的合并日志
+type DockerComposeLogsPage struct {
+	*BaseFlex
+	app      *App
+	logsView *tview.TextView
+	cancelFn context.CancelFunc // 停止读取日志
+
+	mx      sync.Mutex
+	colors  map[string]string // 服务名称 -> 前缀颜色
+	project string            // compose 项目名称
+}
+
+func (_this *DockerComposeLogsPage) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF: ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+	})
+}
+
+// _writeLine 写入一行带服务前缀的日志，多个容器的日志并发写入
+func (_this *DockerComposeLogsPage) _writeLine(source, line string) {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	c, ok := _this.colors[source]
+	if !ok {
+		c = composeLogColors[len(_this.colors)%len(composeLogColors)]
+		_this.colors[source] = c
+	}
+	_, _ = fmt.Fprintf(_this.logsView, "[%s]%s |[-] %s\n", c, tview.Escape(source), tview.Escape(line))
+}
+
+func (_this *DockerComposeLogsPage) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+
+	logsView := tview.NewTextView()
+	logsView.SetDynamicColors(true).
+		SetScrollable(true).
+		SetBorder(false)
+	logsView.SetWrap(true)
+	logsView.SetWordWrap(true)
+	logsView.SetChangedFunc(func() {
+		_this.app.UI.QueueUpdateDraw(func() {
+			logsView.ScrollToEnd() // 自动滚动到最后
+		})
+	})
+	_this.logsView = logsView
+
+	_this.AddItem(logsView, 0, 1, true)
+	return nil
+}
+
+func (_this *DockerComposeLogsPage) Start() {
+	_this.Stop()
+	_this.logsView.Clear()
+
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	go func() {
+		if err := docker_drivers.ComposeProjectLogs(ctx, _this.project, _this._writeLine); err != nil {
+			slog.Error("Failed to get logs for project", "project", _this.project, "error", err)
+			_this.app.UI.QueueUpdateDraw(func() {
+				_this.app.UI.Flash().Err(err)
+			})
+		}
+	}()
+	_this.app.UI.SetFocus(_this)
+}
+
+func (_this *DockerComposeLogsPage) Stop() {
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
+}
+
+func NewDockerComposeLogsPage(app *App, project string) *DockerComposeLogsPage {
+	page := &DockerComposeLogsPage{
+		BaseFlex: NewBaseFlex(fmt.Sprintf("Project Logs: %s", project)),
+		app:      app,
+		colors:   make(map[string]string),
+		project:  project,
+	}
+	return page
+}
@@ -0,0 +1,286 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/26 15:10
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// dockerMarkedColor 已标记容器行的颜色
+const dockerMarkedColor = tcell.ColorFuchsia
+
+// dockerTarget 批量操作的容器
+type dockerTarget struct {
+	id   string
+	name string
+}
+
+// _targets 操作的容器，有标记时为全部已标记的容器，否则为当前选中的容器
+func (_this *DockerBrowser) _targets() []dockerTarget {
+	if len(_this.marked) == 0 {
+		if _this.selectedContainerID == "" {
+			return nil
+		}
+		return []dockerTarget{{id: _this.selectedContainerID, name: _this.selectContainerName}}
+	}
+	targets := make([]dockerTarget, 0, len(_this.marked))
+	for id, name := range _this.marked {
+		targets = append(targets, dockerTarget{id: id, name: name})
+	}
+	// 按名称排序，确认框和结果中的顺序保持稳定
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].name < targets[j].name
+	})
+	return targets
+}
+
+// _describeTargets 确认框中展示的容器列表
+func _describeTargets(targets []dockerTarget) string {
+	names := make([]string, 0, len(targets))
+	for _, t := range targets {
+		names = append(names, t.name)
+	}
+	if len(names) > 5 {
+		names = append(names[:5], fmt.Sprintf("... (%d containers)", len(targets)))
+	}
+	return strings.Join(names, "\n")
+}
+
+// toggleMark 标记或取消标记选中的容器，并移动到下一行
+func (_this *DockerBrowser) toggleMark(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedContainerID == "" {
+		_this.app.UI.Flash().Warn("Only containers can be marked")
+		return nil
+	}
+	fg := dockerMarkedColor
+	if _, ok := _this.marked[_this.selectedContainerID]; ok {
+		delete(_this.marked, _this.selectedContainerID)
+		fg = tcell.ColorWhite
+	} else {
+		_this.marked[_this.selectedContainerID] = _this.selectContainerName
+	}
+	row, _ := _this.containerTableUI.GetSelection()
+	for col := 0; col < _this.containerTableUI.GetColumnCount(); col++ {
+		_this.containerTableUI.GetCell(row, col).SetTextColor(fg)
+	}
+	if row+1 < _this.containerTableUI.GetRowCount() {
+		_this.containerTableUI.Select(row+1, 0)
+	}
+	return nil
+}
+
+// clearMarks 取消全部标记
+func (_this *DockerBrowser) clearMarks(evt *tcell.EventKey) *tcell.EventKey {
+	if len(_this.marked) == 0 {
+		return nil
+	}
+	clear(_this.marked)
+	_this._refreshData()
+	return nil
+}
+
+// startContainers 启动选中或已标记的容器
+func (_this *DockerBrowser) startContainers(evt *tcell.EventKey) *tcell.EventKey {
+	targets := _this._targets()
+	if len(targets) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	_this._confirmBulk("start", targets, func(force bool) {
+		_this._runBulk("Starting", "started", targets, func(t dockerTarget) error {
+			return docker_drivers.StartContainer(t.id)
+		})
+	})
+	return nil
+}
+
+// pauseContainers 暂停运行中的容器，已暂停的容器恢复运行
+func (_this *DockerBrowser) pauseContainers(evt *tcell.EventKey) *tcell.EventKey {
+	targets := _this._targets()
+	if len(targets) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	_this._confirmBulk("pause/unpause", targets, func(force bool) {
+		_this._runBulk("Pausing/Unpausing", "paused/unpaused", targets, func(t dockerTarget) error {
+			detail, err := docker_drivers.InspectContainer(t.id)
+			if err != nil {
+				return err
+			}
+			if detail.State.Paused {
+				return docker_drivers.UnpauseContainer(t.id)
+			}
+			return docker_drivers.PauseContainer(t.id)
+		})
+	})
+	return nil
+}
+
+// killContainers 向选中或已标记的容器发送信号
+func (_this *DockerBrowser) killContainers(evt *tcell.EventKey) *tcell.EventKey {
+	targets := _this._targets()
+	if len(targets) == 0 {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	dialog.ShowKillDockerContainer(&config.Dialog{}, _this.app.Content.Pages, &dialog.KillDockerContainerOpts{
+		Message: _describeTargets(targets),
+		Ack: func(signal string) {
+			_this._runBulk("Killing", "killed with "+signal, targets, func(t dockerTarget) error {
+				return docker_drivers.KillContainer(t.id, signal)
+			})
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		},
+	})
+	return nil
+}
+
+// renameContainer 重命名选中的容器，只能单个操作
+func (_this *DockerBrowser) renameContainer(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedContainerID == "" {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	id, oldName := _this.selectedContainerID, _this.selectContainerName
+	dialog.ShowRenameDockerContainer(&config.Dialog{}, _this.app.Content.Pages, &dialog.RenameDockerContainerOpts{
+		Name: oldName,
+		Ack: func(name string) bool {
+			if name == "" {
+				_this.app.UI.Flash().Err(fmt.Errorf("container name is required"))
+				return false
+			}
+			if name == oldName {
+				return true
+			}
+			if err := docker_drivers.RenameContainer(id, name); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			if _, ok := _this.marked[id]; ok {
+				_this.marked[id] = name
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Container:%s renamed to %s", oldName, name))
+			_this._refreshData()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		},
+	})
+	return nil
+}
+
+// _restartMarked 重启已标记的容器
+func (_this *DockerBrowser) _restartMarked() {
+	targets := _this._targets()
+	_this._confirmBulk("restart", targets, func(force bool) {
+		var timeout *int
+		if force {
+			timeout = helper.Ptr(0)
+		}
+		_this._runBulk("Restarting", "restarted", targets, func(t dockerTarget) error {
+			return docker_drivers.RestartContainer(t.id, timeout)
+		})
+	})
+}
+
+// _stopDeleteMarked 停止已标记的运行中容器，删除已停止的容器
+func (_this *DockerBrowser) _stopDeleteMarked() {
+	targets := _this._targets()
+	_this._confirmBulk("stop or delete", targets, func(force bool) {
+		var timeout *int
+		if force {
+			timeout = helper.Ptr(0)
+		}
+		_this._runBulk("Stopping/Deleting", "stopped or deleted", targets, func(t dockerTarget) error {
+			detail, err := docker_drivers.InspectContainer(t.id)
+			if err != nil {
+				return err
+			}
+			if !detail.State.Running {
+				return docker_drivers.RemoveContainer(t.id, force)
+			}
+			if err = docker_drivers.StopContainer(t.id, timeout); err != nil {
+				return err
+			}
+			return docker_drivers.WaitContainerStopped(t.id, time.Duration(60)*time.Second)
+		})
+	})
+}
+
+// _confirmBulk 操作前确认一次
+func (_this *DockerBrowser) _confirmBulk(operation string, targets []dockerTarget, ack func(force bool)) {
+	title := fmt.Sprintf("Are you sure you want to %s the container?", operation)
+	if len(targets) > 1 {
+		title = fmt.Sprintf("Are you sure you want to %s %d containers?", operation, len(targets))
+	}
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		title,
+		_describeTargets(targets),
+		ack,
+		func() {
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		})
+}
+
+// _runBulk 依次对容器执行操作并展示进度，单个容器失败不影响其余容器
+func (_this *DockerBrowser) _runBulk(verb, done string, targets []dockerTarget, fn func(t dockerTarget) error) {
+	loading := dialog.ShowLoadingDialog(
+		_this.app.Content.Pages,
+		fmt.Sprintf("⏳ %s %d containers...", verb, len(targets)),
+		_this.app.UI.ForceDraw,
+	)
+	go func() {
+		var errs error
+		failed := 0
+		for i, t := range targets {
+			_this.app.UI.QueueUpdateDraw(func() {
+				loading.SetMessage(fmt.Sprintf("⏳ %s %s (%d/%d)...", verb, t.name, i+1, len(targets)))
+			})
+			if err := fn(t); err != nil {
+				slog.Warn("Container operation failed", "container", t.name, "operation", verb, "error", err)
+				errs = errors.Join(errs, err)
+				failed++
+			}
+		}
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			switch {
+			case errs != nil && len(targets) > 1:
+				_this.app.UI.Flash().Err(fmt.Errorf("%d of %d containers failed: %w", failed, len(targets), errs))
+			case errs != nil:
+				_this.app.UI.Flash().Err(errs)
+			case len(targets) > 1:
+				_this.app.UI.Flash().Info(fmt.Sprintf("%d containers %s successfully", len(targets), done))
+			default:
+				_this.app.UI.Flash().Info(fmt.Sprintf("Container:%s %s successfully", targets[0].name, done))
+			}
+			// 操作完成后取消标记
+			clear(_this.marked)
+			_this._refreshData()
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		})
+	}()
+}
+
+// isMarking 是否有已标记的容器，有标记时重启和停止/删除作用于全部已标记的容器
+func (_this *DockerBrowser) isMarking() bool {
+	return len(_this.marked) > 0
+}
@@ -0,0 +1,285 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/27 15:00
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/tview"
+	"github.com/moby/moby/api/types/container"
+)
+
+// 容器详情的标签页
+const (
+	dockerTabInspect = iota
+	dockerTabTop
+	dockerTabDiff
+	dockerTabHealth
+)
+
+var dockerTabNames = []string{"Inspect", "Top", "Diff", "Health"}
+
+// _initTabs 初始化容器详情的标签栏和各标签页
+func (_this *DockerInspectView) _initTabs() {
+	_this.SetDirection(tview.FlexRow)
+	_this.tabBarUI = tview.NewTextView()
+	_this.tabBarUI.SetDynamicColors(true).
+		SetRegions(true).
+		SetBorderPadding(0, 0, 2, 2)
+	var b strings.Builder
+	for i, name := range dockerTabNames {
+		_, _ = fmt.Fprintf(&b, `["%d"] %d %s [""]  `, i, i+1, name)
+	}
+	_this.tabBarUI.SetText(b.String())
+
+	_this.topTableUI = newDockerTabTable()
+	_this.diffTableUI = newDockerTabTable()
+	_this.healthViewUI = tview.NewTextView()
+	_this.healthViewUI.SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetBorderPadding(1, 1, 2, 2)
+
+	_this.tabPagesUI = tview.NewPages()
+	for i, p := range []tview.Primitive{_this.inspectViewUI, _this.topTableUI, _this.diffTableUI, _this.healthViewUI} {
+		_this.tabPagesUI.AddPage(dockerTabNames[i], p, true, i == dockerTabInspect)
+	}
+	_this.AddItem(_this.tabBarUI, 1, 0, false)
+	_this.AddItem(_this.tabPagesUI, 0, 1, true)
+}
+
+func newDockerTabTable() *tview.Table {
+	t := tview.NewTable()
+	t.SetBorderPadding(1, 1, 2, 2)
+	t.SetSelectable(true, false)
+	t.SetFixed(1, 0)
+	return t
+}
+
+func (_this *DockerInspectView) switchTabCmd(tab int) func(evt *tcell.EventKey) *tcell.EventKey {
+	return func(evt *tcell.EventKey) *tcell.EventKey {
+		_this._switchTab(tab)
+		return nil
+	}
+}
+
+func (_this *DockerInspectView) nextTab(evt *tcell.EventKey) *tcell.EventKey {
+	_this._switchTab((_this.currentTab + 1) % len(dockerTabNames))
+	return nil
+}
+
+func (_this *DockerInspectView) refreshTab(evt *tcell.EventKey) *tcell.EventKey {
+	_this._refreshTab()
+	return nil
+}
+
+// _switchTab 切换标签页并刷新其内容
+func (_this *DockerInspectView) _switchTab(tab int) {
+	_this.currentTab = tab
+	_this.tabBarUI.Highlight(fmt.Sprint(tab))
+	_this.tabPagesUI.SwitchToPage(dockerTabNames[tab])
+	_this._refreshTab()
+	_, p := _this.tabPagesUI.GetFrontPage()
+	_this.app.UI.SetFocus(p)
+}
+
+func (_this *DockerInspectView) _refreshTab() {
+	switch _this.currentTab {
+	case dockerTabInspect:
+		_this._refreshInspectView()
+	case dockerTabTop:
+		_this._refreshTop()
+	case dockerTabDiff:
+		_this._refreshDiff()
+	case dockerTabHealth:
+		_this._refreshHealth()
+	}
+}
+
+// _startTabRefresh 定时刷新进程和健康检查标签页
+func (_this *DockerInspectView) _startTabRefresh() {
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(dockerRefreshInterval(_this.app))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_this.app.UI.QueueUpdateDraw(func() {
+					if _this.currentTab == dockerTabTop || _this.currentTab == dockerTabHealth {
+						_this._refreshTab()
+					}
+				})
+			}
+		}
+	}()
+}
+
+// _refreshTop 刷新容器内的进程列表，与 docker top 相同
+func (_this *DockerInspectView) _refreshTop() {
+	top, err := docker_drivers.ContainerTop(_this.inspectId)
+	if err != nil {
+		setDockerTabMessage(_this.topTableUI, err.Error())
+		return
+	}
+	row, _ := _this.topTableUI.GetSelection()
+	_this.topTableUI.Clear()
+	setDockerTabHeader(_this.topTableUI, top.Titles)
+	for i, process := range top.Processes {
+		for col, value := range process {
+			cell := tview.NewTableCell(tview.Escape(value)).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignLeft)
+			// 最后一列为命令，占用剩余宽度
+			if col == len(process)-1 {
+				cell.SetExpansion(1)
+			}
+			_this.topTableUI.SetCell(i+1, col, cell)
+		}
+	}
+	_this.topTableUI.Select(min(max(row, 1), max(len(top.Processes), 1)), 0)
+}
+
+// _refreshDiff 刷新容器文件系统相对镜像的变更，与 docker diff 相同
+func (_this *DockerInspectView) _refreshDiff() {
+	changes, err := docker_drivers.ContainerDiff(_this.inspectId)
+	if err != nil {
+		setDockerTabMessage(_this.diffTableUI, err.Error())
+		return
+	}
+	_this.diffTableUI.Clear()
+	modified, added, deleted := docker_drivers.CountFilesystemChanges(changes)
+	setDockerTabHeader(_this.diffTableUI, []string{
+		"Kind",
+		fmt.Sprintf("Path (%d changed, %d added, %d deleted)", modified, added, deleted),
+	})
+	if len(changes) == 0 {
+		_this.diffTableUI.SetCell(1, 1, tview.NewTableCell("No changes").SetTextColor(tcell.ColorGray))
+		return
+	}
+	for i, c := range changes {
+		color := tcell.ColorYellow
+		switch c.Kind {
+		case container.ChangeAdd:
+			color = tcell.ColorGreen
+		case container.ChangeDelete:
+			color = tcell.ColorRed
+		}
+		_this.diffTableUI.SetCell(i+1, 0, tview.NewTableCell(c.Kind.String()).
+			SetTextColor(color).
+			SetAlign(tview.AlignLeft))
+		_this.diffTableUI.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(c.Path)).
+			SetTextColor(color).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1))
+	}
+}
+
+// _refreshHealth 刷新健康检查的配置、状态和最近的检查输出
+func (_this *DockerInspectView) _refreshHealth() {
+	detail, err := docker_drivers.InspectContainer(_this.inspectId)
+	if err != nil {
+		_this.healthViewUI.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+		return
+	}
+	var cfg *container.HealthConfig
+	if detail.Config != nil {
+		cfg = detail.Config.Healthcheck
+	}
+	var health *container.Health
+	if detail.State != nil {
+		health = detail.State.Health
+	}
+	_this.healthViewUI.SetText(formatDockerHealth(cfg, health))
+}
+
+// formatDockerHealth 健康检查的展示文本，最近的检查结果在前
+func formatDockerHealth(cfg *container.HealthConfig, health *container.Health) string {
+	if cfg == nil || len(cfg.Test) == 0 || cfg.Test[0] == "NONE" {
+		return "[gray]No health check configured for this container"
+	}
+	var b strings.Builder
+	test := cfg.Test
+	if test[0] == "CMD" || test[0] == "CMD-SHELL" {
+		test = test[1:]
+	}
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%s\n", "Test", tview.Escape(strings.Join(test, " ")))
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%s\n", "Interval", healthDuration(cfg.Interval, 30*time.Second))
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%s\n", "Timeout", healthDuration(cfg.Timeout, 30*time.Second))
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%s\n", "Start Period", healthDuration(cfg.StartPeriod, 0))
+	retries := cfg.Retries
+	if retries == 0 {
+		retries = 3
+	}
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%d\n\n", "Retries", retries)
+	if health == nil {
+		b.WriteString("[gray]No health check results yet, the container may not be running")
+		return b.String()
+	}
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[%s]%s[white]\n", "Status", healthColor(health.Status), health.Status)
+	_, _ = fmt.Fprintf(&b, "[yellow]%-16s[white]%d\n", "Failing Streak", health.FailingStreak)
+	for i := len(health.Log) - 1; i >= 0; i-- {
+		probe := health.Log[i]
+		color := "green"
+		if probe.ExitCode != 0 {
+			color = "red"
+		}
+		_, _ = fmt.Fprintf(&b, "\n[%s]● %s  exit %d  %s[white]\n",
+			color,
+			probe.Start.Local().Format("2006-01-02 15:04:05"),
+			probe.ExitCode,
+			probe.End.Sub(probe.Start).Round(time.Millisecond))
+		if output := strings.TrimSpace(probe.Output); output != "" {
+			b.WriteString(tview.Escape(output))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// healthDuration 未设置时展示 Docker 的默认值
+func healthDuration(d, def time.Duration) string {
+	if d == 0 {
+		d = def
+	}
+	return d.String()
+}
+
+func healthColor(status container.HealthStatus) string {
+	switch status {
+	case container.Healthy:
+		return "green"
+	case container.Unhealthy:
+		return "red"
+	default:
+		return "yellow"
+	}
+}
+
+func setDockerTabHeader(t *tview.Table, titles []string) {
+	for i, title := range titles {
+		t.SetCell(0, i, tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false))
+	}
+}
+
+// setDockerTabMessage 表格中展示错误等提示，如容器未运行时无法获取进程
+func setDockerTabMessage(t *tview.Table, message string) {
+	t.Clear()
+	t.SetCell(0, 0, tview.NewTableCell(tview.Escape(message)).
+		SetTextColor(tcell.ColorRed).
+		SetSelectable(false))
+}
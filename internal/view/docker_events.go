@@ -0,0 +1,167 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/26 10:50
+ */
+
+package view
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
+)
+
+const (
+	dockerEventHistorySize = 500                    // 保留的最近事件数量
+	dockerEventDebounce    = 200 * time.Millisecond // 合并短时间内的多个容器事件后再更新列表
+)
+
+// dockerContainerChanges 一批容器事件涉及的容器及其所属 compose 项目的最新状态
+type dockerContainerChanges struct {
+	containers map[string]*container.Summary   // 容器ID -> 最新状态，已删除的容器为 nil
+	projects   map[string][]*container.Summary // compose 项目 -> 项目下的容器
+}
+
+// _startEventsWatch 订阅引擎事件，记录事件历史并按容器事件增量更新列表
+func (_this *DockerBrowser) _startEventsWatch(since time.Time) {
+	var ctx context.Context
+	ctx, _this.eventsCancelFn = context.WithCancel(context.Background())
+	changed := make(chan string, 64)
+	go docker_drivers.WatchEvents(ctx, since, func(msg events.Message) {
+		_this.events.Add(msg)
+		if !docker_drivers.IsContainerLifecycleEvent(msg) {
+			return
+		}
+		select {
+		case changed <- msg.Actor.ID:
+		case <-ctx.Done():
+		}
+	})
+	go func() {
+		pending := make(map[string]struct{})
+		timer := time.NewTimer(dockerEventDebounce)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case id := <-changed:
+				if len(pending) == 0 {
+					timer.Reset(dockerEventDebounce)
+				}
+				pending[id] = struct{}{}
+			case <-timer.C:
+				changes := _this._fetchContainerChanges(pending)
+				pending = make(map[string]struct{})
+				_this.app.UI.QueueUpdateDraw(func() {
+					if ctx.Err() == nil {
+						_this._applyContainerChanges(changes)
+					}
+				})
+			}
+		}
+	}()
+}
+
+// _fetchContainerChanges 获取发生变化的容器以及所属 compose 项目的最新状态
+func (_this *DockerBrowser) _fetchContainerChanges(ids map[string]struct{}) *dockerContainerChanges {
+	changes := &dockerContainerChanges{
+		containers: make(map[string]*container.Summary),
+		projects:   make(map[string][]*container.Summary),
+	}
+	for id := range ids {
+		ctr, err := docker_drivers.GetContainer(id)
+		if err != nil {
+			slog.Warn("Failed to get changed container", "containerID", id, "error", err)
+			continue
+		}
+		changes.containers[id] = ctr
+		if ctr == nil {
+			continue
+		}
+		project := ctr.Labels[docker_drivers.ComposeProjectLabel]
+		if _, ok := changes.projects[project]; project == "" || ok {
+			continue
+		}
+		if changes.projects[project], err = docker_drivers.ListComposeContainers(project); err != nil {
+			slog.Warn("Failed to list compose containers", "project", project, "error", err)
+			delete(changes.projects, project)
+		}
+	}
+	return changes
+}
+
+// _applyContainerChanges 原地更新已有的行，容器新增、删除或位于折叠的项目中时重新加载整个列表
+func (_this *DockerBrowser) _applyContainerChanges(changes *dockerContainerChanges) {
+	rows := make(map[string]int)
+	projectRows := make(map[string]int)
+	for row := 1; row < _this.containerTableUI.GetRowCount(); row++ {
+		ref, ok := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+		if !ok {
+			continue
+		}
+		if ref.id == "" {
+			projectRows[ref.project] = row
+		} else {
+			rows[ref.id] = row
+		}
+	}
+	for id, ctr := range changes.containers {
+		row, ok := rows[id]
+		if ctr == nil || !ok {
+			_this._refreshData()
+			return
+		}
+		ref := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+		_this._setContainerRow(row, ctr, ref.project)
+	}
+	for project, containers := range changes.projects {
+		row, ok := projectRows[project]
+		if !ok || len(containers) == 0 {
+			_this._refreshData()
+			return
+		}
+		members := make([]string, 0, len(containers))
+		for _, ctr := range containers {
+			if ctr.State == "running" {
+				members = append(members, shortDockerID(ctr.ID))
+			}
+		}
+		_this._setProjectRow(row, &docker_drivers.ComposeProject{Name: project, Containers: containers}, members)
+	}
+	_this.stats.Track(_this._runningContainers())
+	_this._refreshStats()
+	selected, _ := _this.containerTableUI.GetSelection()
+	_this._syncSelection(selected)
+}
+
+// _runningContainers 列表中运行中容器的短ID，包括折叠项目下的容器
+func (_this *DockerBrowser) _runningContainers() []string {
+	seen := make(map[string]bool)
+	var running []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			running = append(running, id)
+		}
+	}
+	for row := 1; row < _this.containerTableUI.GetRowCount(); row++ {
+		ref, ok := _this.containerTableUI.GetCell(row, 0).GetReference().(*dockerRowRef)
+		if !ok {
+			continue
+		}
+		if ref.id == "" {
+			for _, id := range ref.members {
+				add(id)
+			}
+		} else if _this.containerTableUI.GetCell(row, 5).Text == "running" {
+			add(shortDockerID(ref.id))
+		}
+	}
+	return running
+}
@@ -0,0 +1,218 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/26 11:30
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/view/base"
+	"github.com/liangzhaoliang95/tview"
+	"github.com/moby/moby/api/types/events"
+)
+
+// dockerEventsRenderInterval 有新事件时重绘列表的间隔
+const dockerEventsRenderInterval = 200 * time.Millisecond
+
+// dockerEventTypes 类型过滤依次切换的事件类型，空字符串表示全部
+var dockerEventTypes = []events.Type{
+	"",
+	events.ContainerEventType,
+	events.ImageEventType,
+	events.NetworkEventType,
+	events.VolumeEventType,
+	events.DaemonEventType,
+	events.PluginEventType,
+	events.BuilderEventType,
+}
+
+type DockerEventsView struct {
+	*BaseFlex
+	app         *App
+	filterFlex  *tview.Flex       // 动作过滤框布局
+	filterInput *tview.InputField // 动作过滤，按前缀匹配
+	eventsUI    *tview.Table
+	history     *docker_drivers.EventHistory
+	filter      docker_drivers.EventFilter
+	typeIndex   int         // 当前类型过滤在 dockerEventTypes 中的位置
+	dirty       atomic.Bool // 有未渲染的新事件
+	cancelFn    context.CancelFunc
+}
+
+func (_this *DockerEventsView) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF:         ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeyY:         ui.NewKeyAction("Type Filter", _this.cycleType, true),
+		ui.KeySlash:     ui.NewKeyAction("Action Filter", _this.toggleFilter, true),
+		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
+	})
+}
+
+// cycleType 依次切换事件类型过滤
+func (_this *DockerEventsView) cycleType(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.typeIndex = (_this.typeIndex + 1) % len(dockerEventTypes)
+	_this.filter.Type = dockerEventTypes[_this.typeIndex]
+	_this._render()
+	return nil
+}
+
+// toggleFilter 切换焦点到动作过滤框
+func (_this *DockerEventsView) toggleFilter(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.app.UI.SetFocus(_this.filterInput)
+	return nil
+}
+
+// _render 按过滤条件渲染事件，最新的事件显示在最上方
+func (_this *DockerEventsView) _render() {
+	_this.dirty.Store(false)
+	list := _this.history.List(_this.filter)
+	_this.eventsUI.Clear()
+	for i, header := range []string{"Time", "Type", "Action", "Name", "Attributes"} {
+		_this.eventsUI.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1).
+			SetSelectable(false))
+	}
+	for i := range list {
+		msg := list[len(list)-1-i]
+		columns := []string{
+			docker_drivers.EventTime(msg).Local().Format("2006-01-02 15:04:05"),
+			string(msg.Type),
+			string(msg.Action),
+			docker_drivers.EventActorName(msg),
+			docker_drivers.FormatEventAttributes(msg),
+		}
+		for col, text := range columns {
+			cell := tview.NewTableCell(tview.Escape(text)).
+				SetTextColor(dockerEventColor(msg)).
+				SetAlign(tview.AlignLeft)
+			if col == len(columns)-1 {
+				cell.SetExpansion(1)
+			}
+			_this.eventsUI.SetCell(i+1, col, cell)
+		}
+	}
+	_this.SetTitle(fmt.Sprintf(" Docker Events [%s %d] ", _this.filter, len(list)))
+}
+
+// dockerEventColor 按动作区分颜色，异常退出和删除标红
+func dockerEventColor(msg events.Message) tcell.Color {
+	switch {
+	case msg.Action == events.ActionDie || msg.Action == events.ActionOOM || msg.Action == events.ActionKill ||
+		msg.Action == events.ActionHealthStatusUnhealthy:
+		return tcell.ColorRed
+	case msg.Action == events.ActionDestroy || msg.Action == events.ActionDelete || msg.Action == events.ActionRemove:
+		return tcell.ColorOrange
+	case msg.Action == events.ActionStart || msg.Action == events.ActionCreate || msg.Action == events.ActionPull:
+		return tcell.ColorGreen
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+func (_this *DockerEventsView) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.SetDirection(tview.FlexRow)
+
+	// 初始化动作过滤框
+	_this.filterFlex = tview.NewFlex()
+	_this.filterFlex.SetDirection(tview.FlexColumn)
+	_this.filterFlex.SetBorder(true)
+	_this.filterFlex.SetBorderPadding(0, 0, 1, 1)
+	filterLabel := tview.NewTextView()
+	filterLabel.SetText("Action: ")
+	filterLabel.SetTextAlign(tview.AlignCenter)
+	filterLabel.SetTextColor(tcell.ColorGreen)
+	_this.filterFlex.AddItem(filterLabel, 8, 1, false)
+
+	_this.filterInput = tview.NewInputField()
+	_this.filterInput.SetPlaceholder("Enter an action prefix such as start, die or exec_, Y to filter by type")
+	_this.filterInput.SetFieldBackgroundColor(tcell.ColorBlack)
+	_this.filterInput.SetFieldTextColor(tcell.ColorRed)
+	_this.filterInput.SetFocusFunc(func() {
+		_this.filterFlex.SetBorderColor(base.ActiveBorderColor)
+	})
+	_this.filterInput.SetBlurFunc(func() {
+		_this.filterFlex.SetBorderColor(base.InactiveBorderColor)
+	})
+	_this.filterInput.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			_this.filter.Action = strings.TrimSpace(_this.filterInput.GetText())
+			_this._render()
+		}
+		_this.app.UI.SetFocus(_this.eventsUI)
+	})
+	_this.filterFlex.AddItem(_this.filterInput, 0, 5, true)
+	_this.AddItem(_this.filterFlex, 3, 0, false)
+
+	_this.eventsUI = tview.NewTable()
+	_this.eventsUI.SetBorderPadding(0, 0, 1, 1)
+	_this.eventsUI.SetSelectable(true, false)
+	_this.eventsUI.SetFixed(1, 0)
+	_this.AddItem(_this.eventsUI, 0, 1, true)
+	return nil
+}
+
+func (_this *DockerEventsView) Start() {
+	_this.Stop()
+	_this._render()
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	since := _this.history.Last()
+	if since.IsZero() {
+		since = time.Now()
+	}
+	go docker_drivers.WatchEvents(ctx, since, func(msg events.Message) {
+		_this.history.Add(msg)
+		_this.dirty.Store(true)
+	})
+	go func() {
+		ticker := time.NewTicker(dockerEventsRenderInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _this.dirty.Load() {
+					_this.app.UI.QueueUpdateDraw(_this._render)
+				}
+			}
+		}
+	}()
+	_this.app.UI.SetFocus(_this.eventsUI)
+}
+
+func (_this *DockerEventsView) Stop() {
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
+}
+
+// NewDockerEventsView 事件列表，与容器列表共用事件历史
+func NewDockerEventsView(app *App, history *docker_drivers.EventHistory) *DockerEventsView {
+	v := &DockerEventsView{
+		BaseFlex: NewBaseFlex("Docker Events"),
+		app:      app,
+		history:  history,
+	}
+	return v
+}
@@ -0,0 +1,81 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 10:45
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"golang.org/x/term"
+)
+
+// runNativeExec 挂起界面，通过 Docker API 在容器内打开交互式 shell，无需 docker 命令行
+func runNativeExec(a *App, containerID, banner string) error {
+	var execErr error
+	a.Halt()
+	defer a.Resume()
+
+	suspended := a.UI.Suspend(func() {
+		execErr = nativeExec(containerID, banner)
+	})
+	if !suspended {
+		return fmt.Errorf("unable to run command")
+	}
+	return execErr
+}
+
+func nativeExec(containerID, banner string) error {
+	clearScreen()
+	defer clearScreen()
+
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	width, height, err := term.GetSize(outFd)
+	if err != nil {
+		slog.Warn("Failed to get terminal size", "error", err)
+	}
+	// 优先使用 bash，不存在时回退到 sh
+	session, err := docker_drivers.ExecAttach(containerID, []string{"sh", "-c", shellCheck}, uint(height), uint(width))
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	// raw 模式下换行不会回到行首，需在切换前输出提示
+	_, _ = os.Stdout.WriteString(banner)
+	if term.IsTerminal(inFd) {
+		state, err := term.MakeRaw(inFd)
+		if err != nil {
+			return fmt.Errorf("failed to set terminal raw mode: %w", err)
+		}
+		defer func() {
+			_ = term.Restore(inFd, state)
+		}()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go watchResize(done, outFd, session)
+
+	reader, writer := session.Stream()
+	go func() {
+		copyInput(done, writer)
+		_ = session.CloseWrite()
+	}()
+	if _, err = io.Copy(os.Stdout, reader); err != nil && !errors.Is(err, io.EOF) {
+		slog.Warn("Exec output stream closed", "container", containerID, "error", err)
+	}
+
+	code, err := session.ExitCode()
+	if err != nil {
+		return err
+	}
+	slog.Info("Exec finished", "container", containerID, "exitCode", code)
+	return nil
+}
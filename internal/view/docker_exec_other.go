@@ -0,0 +1,48 @@
+//go:build !unix
+
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 11:10
+ */
+
+package view
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"golang.org/x/term"
+)
+
+// resizePollInterval 没有 SIGWINCH 时轮询终端尺寸的间隔
+const resizePollInterval = 500 * time.Millisecond
+
+// watchResize 定时检查终端尺寸变化并同步到 exec 的 TTY
+func watchResize(done <-chan struct{}, fd int, session *docker_drivers.ExecSession) {
+	ticker := time.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+	lastWidth, lastHeight, _ := term.GetSize(fd)
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			width, height, err := term.GetSize(fd)
+			if err != nil || (width == lastWidth && height == lastHeight) {
+				continue
+			}
+			lastWidth, lastHeight = width, height
+			if err := session.Resize(uint(height), uint(width)); err != nil {
+				slog.Warn("Failed to resize exec tty", "error", err)
+			}
+		}
+	}
+}
+
+// copyInput 将标准输入转发到 exec
+func copyInput(_ <-chan struct{}, w io.Writer) {
+	_, _ = io.Copy(w, os.Stdin)
+}
@@ -0,0 +1,73 @@
+//go:build unix
+
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 11:10
+ */
+
+package view
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// inputPollTimeout 轮询标准输入的超时时间(毫秒)，会话结束后最迟在该时间内停止读取
+const inputPollTimeout = 100
+
+// watchResize 监听终端尺寸变化并同步到 exec 的 TTY
+func watchResize(done <-chan struct{}, fd int, session *docker_drivers.ExecSession) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, unix.SIGWINCH)
+	defer signal.Stop(sigChan)
+	for {
+		select {
+		case <-done:
+			return
+		case <-sigChan:
+			width, height, err := term.GetSize(fd)
+			if err != nil {
+				continue
+			}
+			if err := session.Resize(uint(height), uint(width)); err != nil {
+				slog.Warn("Failed to resize exec tty", "error", err)
+			}
+		}
+	}
+}
+
+// copyInput 将标准输入转发到 exec，会话结束后停止读取，避免吞掉界面恢复后的按键
+func copyInput(done <-chan struct{}, w io.Writer) {
+	fds := []unix.PollFd{{Fd: int32(os.Stdin.Fd()), Events: unix.POLLIN}}
+	buf := make([]byte, 1024)
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		n, err := unix.Poll(fds, inputPollTimeout)
+		if errors.Is(err, unix.EINTR) || (err == nil && n == 0) {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		n, err = os.Stdin.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/25 16:20
+ */
+
+package view
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/slogs"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+func loadDockerConfiguration() (*config.DockerConfig, error) {
+	slog.Info("🐶 lxz docker browser loading configuration...")
+
+	dockerConfig := config.NewDockerConfig()
+	var errs error
+
+	// 读取配置文件中的额外引擎
+	if err := dockerConfig.Load(config.AppDockerConfigFile, false); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := dockerConfig.Save(true); err != nil {
+		slog.Error("lxz docker config save failed", slogs.Error, err)
+		errs = errors.Join(errs, err)
+	} else {
+		slog.Info("lxz docker config saved successfully", slogs.Path, config.AppDockerConfigFile)
+	}
+
+	return dockerConfig, errs
+}
+
+// switchHost 在环境变量、docker context 和配置文件中的引擎之间切换
+func (_this *DockerBrowser) switchHost(evt *tcell.EventKey) *tcell.EventKey {
+	dockerConfig, err := loadDockerConfiguration()
+	if err != nil {
+		slog.Error("Failed to load docker configuration", slogs.Error, err)
+		_this.app.UI.Flash().Warn(fmt.Sprintf("Failed to load docker configuration: %s", err))
+	}
+	hosts := docker_drivers.ListDockerHosts(dockerConfig.DockerHosts)
+	current := docker_drivers.CurrentDockerHost()
+	items := make([]dialog.DockerHostItem, 0, len(hosts))
+	for _, h := range hosts {
+		items = append(items, dialog.DockerHostItem{
+			Name:    h.Name,
+			Address: h.Address(),
+			Current: h.Name == current.Name && h.Host == current.Host,
+		})
+	}
+	dialog.ShowDockerHosts(&config.Dialog{}, _this.app.Content.Pages, &dialog.SwitchDockerHostOpts{
+		Hosts: items,
+		Ack: func(index int) {
+			_this._switchHost(hosts[index])
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		},
+	})
+	return nil
+}
+
+// _switchHost 异步连接引擎，成功后以新的客户端重新加载容器列表
+func (_this *DockerBrowser) _switchHost(host *docker_drivers.DockerHost) {
+	loading := dialog.ShowLoadingDialog(
+		_this.app.Content.Pages,
+		fmt.Sprintf("⏳ Connecting %s (%s)...", host.Name, host.Address()),
+		_this.app.UI.ForceDraw,
+	)
+	go func() {
+		err := docker_drivers.SwitchDockerHost(host)
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				_this.app.UI.SetFocus(_this.containerTableUI)
+				return
+			}
+			// 切换引擎后之前的资源统计和事件不再有效
+			_this.Stop()
+			_this.stats = newDockerStatsCollector()
+			_this.events.Clear()
+			_this.selectedContainerID, _this.selectContainerName, _this.selectedProject = "", "", ""
+			_this._updateTitle()
+			_this.Start()
+			_this.app.UI.Flash().Info(fmt.Sprintf("Switched to docker host %s", host.Name))
+		})
+	}()
+}
+
+// _updateTitle 标题中展示当前连接的引擎
+func (_this *DockerBrowser) _updateTitle() {
+	_this.SetTitle(fmt.Sprintf(" Docker Browser [%s] ", docker_drivers.CurrentDockerHost().Name))
+}
@@ -0,0 +1,253 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 15:20
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/tview"
+)
+
+var dockerImageHeaders = []string{"Repository", "Tag", "Image ID", "Size", "Created", "Containers"}
+
+type DockerImageBrowser struct {
+	*BaseFlex
+	app           *App
+	imageTableUI  *tview.Table
+	selectedImage *docker_drivers.ImageData // 当前选中的镜像
+}
+
+func (_this *DockerImageBrowser) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF:        ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeyI:        ui.NewKeyAction("Detail Info", _this.showDetail, true),
+		ui.KeyH:        ui.NewKeyAction("History", _this.showHistory, true),
+		ui.KeyP:        ui.NewKeyAction("Pull", _this.pullImage, true),
+		ui.KeyR:        ui.NewKeyAction("Run", _this.runImage, true),
+		ui.KeyX:        ui.NewKeyAction("Prune Dangling", _this.pruneImages, true),
+		tcell.KeyCtrlR: ui.NewKeyAction("Refresh", _this.refresh, true),
+		tcell.KeyCtrlD: ui.NewKeyAction("Delete", _this.removeImage, true),
+	})
+}
+
+// showDetail 查看镜像详情
+func (_this *DockerImageBrowser) showDetail(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedImage == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select an image first"))
+		return nil
+	}
+	ref := _this.selectedImage.Reference()
+	if err := _this.app.inject(NewDockerInspectView(_this.app, "image", ref, ref), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker inspect view: %w", err))
+	}
+	return nil
+}
+
+// showHistory 查看镜像的构建层历史
+func (_this *DockerImageBrowser) showHistory(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedImage == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select an image first"))
+		return nil
+	}
+	if err := _this.app.inject(NewDockerImageHistoryView(_this.app, _this.selectedImage.Reference()), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker image history view: %w", err))
+	}
+	return nil
+}
+
+// runImage 以选中的镜像运行新容器
+func (_this *DockerImageBrowser) runImage(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedImage == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select an image first"))
+		return nil
+	}
+	showDockerRun(_this.app, _this.selectedImage.Reference(), _this.imageTableUI, nil)
+	return nil
+}
+
+// pullImage 输入镜像引用并拉取，拉取过程中展示进度
+func (_this *DockerImageBrowser) pullImage(evt *tcell.EventKey) *tcell.EventKey {
+	ref := ""
+	if _this.selectedImage != nil && _this.selectedImage.Repository != "<none>" {
+		ref = _this.selectedImage.Reference()
+	}
+	dialog.ShowDockerImagePull(&config.Dialog{}, _this.app.Content.Pages, &dialog.DockerImagePullOpts{
+		Reference: ref,
+		Ack: func(ref string) {
+			loading := dialog.ShowLoadingDialog(
+				_this.app.Content.Pages,
+				fmt.Sprintf("⏳ Pulling %s...", ref),
+				_this.app.UI.ForceDraw,
+			)
+			go func() {
+				err := docker_drivers.PullImage(ref, func(status string) {
+					_this.app.UI.QueueUpdateDraw(func() {
+						loading.SetMessage(fmt.Sprintf("⏳ Pulling %s\n%s", ref, status))
+					})
+				})
+				_this.app.UI.QueueUpdateDraw(func() {
+					loading.Hide()
+					if err != nil {
+						slog.Error("Failed to pull image", "ref", ref, "error", err)
+						_this.app.UI.Flash().Err(err)
+					} else {
+						_this.app.UI.Flash().Info(fmt.Sprintf("Image %s pulled successfully", ref))
+					}
+					_this._refreshData()
+					_this.app.UI.SetFocus(_this.imageTableUI)
+				})
+			}()
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.imageTableUI)
+		},
+	})
+	return nil
+}
+
+// removeImage 删除选中的镜像，勾选 Force 时强制删除被容器引用的镜像
+func (_this *DockerImageBrowser) removeImage(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedImage == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select an image first"))
+		return nil
+	}
+	img := _this.selectedImage
+	msg := img.Reference()
+	if len(img.Containers) > 0 {
+		msg += fmt.Sprintf("\nUsed by: %s", strings.Join(img.Containers, ", "))
+	}
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to delete the image?",
+		msg,
+		func(force bool) {
+			loading := dialog.ShowLoadingDialog(
+				_this.app.Content.Pages,
+				"",
+				_this.app.UI.ForceDraw,
+			)
+			if err := docker_drivers.RemoveImage(img.Reference(), force); err != nil {
+				_this.app.UI.Flash().Err(err)
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Image %s deleted successfully", img.Reference()))
+				_this._refreshData()
+			}
+			loading.Hide()
+		},
+		func() {
+			_this.app.UI.SetFocus(_this.imageTableUI)
+		})
+	return nil
+}
+
+// pruneImages 清理悬空镜像
+func (_this *DockerImageBrowser) pruneImages(evt *tcell.EventKey) *tcell.EventKey {
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to prune dangling images?",
+		"Untagged images not used by any container will be removed.",
+		func(force bool) {
+			loading := dialog.ShowLoadingDialog(
+				_this.app.Content.Pages,
+				"",
+				_this.app.UI.ForceDraw,
+			)
+			count, reclaimed, err := docker_drivers.PruneDanglingImages()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf(
+					"Pruned %d images, reclaimed %s",
+					count,
+					units.HumanSize(float64(reclaimed)),
+				))
+				_this._refreshData()
+			}
+			loading.Hide()
+		},
+		func() {
+			_this.app.UI.SetFocus(_this.imageTableUI)
+		})
+	return nil
+}
+
+func (_this *DockerImageBrowser) refresh(evt *tcell.EventKey) *tcell.EventKey {
+	_this._refreshData()
+	return nil
+}
+
+func (_this *DockerImageBrowser) _refreshData() {
+	images, err := docker_drivers.ListImages()
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	resetDockerTable(_this.imageTableUI, dockerImageHeaders...)
+	_this.selectedImage = nil
+	for i, img := range images {
+		if i == 0 {
+			_this.selectedImage = img // 默认选中
+		}
+		containers := "-"
+		if len(img.Containers) > 0 {
+			containers = strings.Join(img.Containers, ", ")
+		}
+		_this.imageTableUI.SetCell(i+1, 0, dockerCell(img.Repository, img))
+		_this.imageTableUI.SetCell(i+1, 1, dockerCell(img.Tag, img))
+		_this.imageTableUI.SetCell(i+1, 2, dockerCell(shortDockerID(img.ID), img))
+		_this.imageTableUI.SetCell(i+1, 3, dockerCell(units.HumanSize(float64(img.Size)), img))
+		_this.imageTableUI.SetCell(i+1, 4, dockerCell(helper.TimeFormat(img.Created), img))
+		_this.imageTableUI.SetCell(i+1, 5, dockerCell(containers, img))
+	}
+	_this.imageTableUI.SetTitle(fmt.Sprintf("🐳 Images (%d)", len(images)))
+	_this.imageTableUI.Select(1, 0)
+}
+
+func (_this *DockerImageBrowser) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.imageTableUI = newDockerTable("🐳 Images", dockerImageHeaders...)
+	// 设置表格的选择模式
+	_this.imageTableUI.SetSelectionChangedFunc(func(row, column int) {
+		if row < 1 || row >= _this.imageTableUI.GetRowCount() {
+			return
+		}
+		if img, ok := _this.imageTableUI.GetCell(row, 0).GetReference().(*docker_drivers.ImageData); ok {
+			_this.selectedImage = img
+		}
+	})
+
+	_this.AddItem(_this.imageTableUI, 0, 1, true)
+
+	return nil
+}
+
+func (_this *DockerImageBrowser) Start() {
+	_this._refreshData()
+
+	_this.app.UI.SetFocus(_this.imageTableUI)
+}
+
+func (_this *DockerImageBrowser) Stop() {}
+
+func NewDockerImageBrowser(app *App) *DockerImageBrowser {
+	var name = "Docker Images"
+	f := &DockerImageBrowser{
+		BaseFlex: NewBaseFlex(name),
+		app:      app,
+	}
+	return f
+}
@@ -0,0 +1,83 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 16:05
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+var dockerImageHistoryHeaders = []string{"Image ID", "Created", "Created By", "Size", "Comment"}
+
+type DockerImageHistoryView struct {
+	*BaseFlex
+	app            *App
+	historyTableUI *tview.Table
+	imageRef       string // 镜像引用
+}
+
+func (_this *DockerImageHistoryView) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF: ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+	})
+}
+
+func (_this *DockerImageHistoryView) _refreshData() {
+	history, err := docker_drivers.ImageHistory(_this.imageRef)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	resetDockerTable(_this.historyTableUI, dockerImageHistoryHeaders...)
+	for i, layer := range history {
+		id := "<missing>"
+		if layer.ID != "<missing>" {
+			id = shortDockerID(layer.ID)
+		}
+		// 构建指令中的换行和多余空白压缩为一行展示
+		createdBy := strings.Join(strings.Fields(layer.CreatedBy), " ")
+		_this.historyTableUI.SetCell(i+1, 0, dockerCell(id, layer.ID))
+		_this.historyTableUI.SetCell(i+1, 1, dockerCell(helper.TimeFormat(layer.Created), layer.ID))
+		_this.historyTableUI.SetCell(i+1, 2, dockerCell(createdBy, layer.CreatedBy).SetMaxWidth(80))
+		_this.historyTableUI.SetCell(i+1, 3, dockerCell(units.HumanSize(float64(layer.Size)), layer.ID))
+		_this.historyTableUI.SetCell(i+1, 4, dockerCell(layer.Comment, layer.ID))
+	}
+	_this.historyTableUI.SetTitle(fmt.Sprintf("📜 History %s (%d layers)", _this.imageRef, len(history)))
+	_this.historyTableUI.Select(1, 0)
+}
+
+func (_this *DockerImageHistoryView) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.historyTableUI = newDockerTable("📜 History", dockerImageHistoryHeaders...)
+	_this.AddItem(_this.historyTableUI, 0, 1, true)
+	return nil
+}
+
+func (_this *DockerImageHistoryView) Start() {
+	_this._refreshData()
+
+	_this.app.UI.SetFocus(_this.historyTableUI)
+}
+
+func (_this *DockerImageHistoryView) Stop() {}
+
+func NewDockerImageHistoryView(app *App, imageRef string) *DockerImageHistoryView {
+	var name = fmt.Sprintf("History: %s", imageRef)
+	f := &DockerImageHistoryView{
+		BaseFlex: NewBaseFlex(name),
+		app:      app,
+		imageRef: imageRef,
+	}
+	return f
+}
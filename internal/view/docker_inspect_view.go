@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
 	"github.com/liangzhaoliang95/lxz/internal/helper"
 	"github.com/liangzhaoliang95/lxz/internal/ui"
@@ -22,12 +23,32 @@ type DockerInspectView struct {
 	inspectId     string          // 当前选中的容器ID
 	inspectName   string          // 当前选中的容器名称
 	inspectType   string          // image container network volume
+
+	// 容器的 Inspect、进程、文件变更和健康检查标签页
+	tabBarUI     *tview.TextView
+	tabPagesUI   *tview.Pages
+	topTableUI   *tview.Table
+	diffTableUI  *tview.Table
+	healthViewUI *tview.TextView
+	currentTab   int
+	cancelFn     context.CancelFunc // 停止进程和健康检查的定时刷新
 }
 
 func (_this *DockerInspectView) bindKeys() {
 	_this.Actions().Bulk(ui.KeyMap{
 		ui.KeyF: ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
 	})
+	if _this.inspectType != "container" {
+		return
+	}
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.Key1:        ui.NewKeyAction("Inspect", _this.switchTabCmd(dockerTabInspect), true),
+		ui.Key2:        ui.NewKeyAction("Top", _this.switchTabCmd(dockerTabTop), true),
+		ui.Key3:        ui.NewKeyAction("Diff", _this.switchTabCmd(dockerTabDiff), true),
+		ui.Key4:        ui.NewKeyAction("Health", _this.switchTabCmd(dockerTabHealth), true),
+		tcell.KeyTab:   ui.NewKeyAction("Next Tab", _this.nextTab, true),
+		tcell.KeyCtrlR: ui.NewKeyAction("Refresh", _this.refreshTab, true),
+	})
 }
 
 func (_this *DockerInspectView) _refreshInspectView() {
@@ -62,7 +83,6 @@ func (_this *DockerInspectView) _inspectContainer() {
 		output = helper.Prettify(res)
 	}
 	_this.inspectViewUI.SetText(output)
-	_this.inspectViewUI.SetText(helper.Prettify(res))
 }
 
 func (_this *DockerInspectView) _inspectImage() {
@@ -82,11 +102,33 @@ func (_this *DockerInspectView) _inspectImage() {
 }
 
 func (_this *DockerInspectView) _inspectNetwork() {
-
+	res, err := docker_drivers.InspectNetwork(_this.inspectId)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	var output string
+	if res == nil {
+		output = "No network found with the given ID."
+	} else {
+		output = helper.Prettify(res)
+	}
+	_this.inspectViewUI.SetText(output)
 }
 
 func (_this *DockerInspectView) _inspectVolume() {
-
+	res, err := docker_drivers.InspectVolume(_this.inspectId)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	var output string
+	if res == nil {
+		output = "No volume found with the given name."
+	} else {
+		output = helper.Prettify(res)
+	}
+	_this.inspectViewUI.SetText(output)
 }
 
 func (_this *DockerInspectView) Init(ctx context.Context) error {
@@ -97,6 +139,10 @@ func (_this *DockerInspectView) Init(ctx context.Context) error {
 	_this.inspectViewUI.SetTitle("")
 	_this.inspectViewUI.SetWrap(true)
 	_this.inspectViewUI.SetBorderPadding(1, 1, 2, 2)
+	if _this.inspectType == "container" {
+		_this._initTabs()
+		return nil
+	}
 	_this.AddItem(_this.inspectViewUI, 0, 1, true)
 
 	return nil
@@ -104,13 +150,22 @@ func (_this *DockerInspectView) Init(ctx context.Context) error {
 
 func (_this *DockerInspectView) Start() {
 	// ✅ 设置默认边框颜色 + 焦点 + 强制刷新
+	if _this.inspectType == "container" {
+		_this.Stop()
+		_this._switchTab(_this.currentTab)
+		_this._startTabRefresh()
+		return
+	}
 	_this._refreshInspectView()
 
 	_this.app.UI.SetFocus(_this.inspectViewUI)
 }
 
 func (_this *DockerInspectView) Stop() {
-
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
 }
 
 // --- HELPER FUNCTIONS ---
@@ -4,16 +4,49 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
 	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/render"
 	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/lxz/internal/view/base"
 	"github.com/liangzhaoliang95/tview"
 )
 
+// dockerLogsFlushInterval 新日志写入界面的间隔，避免日志量大时频繁重绘
+const dockerLogsFlushInterval = 100 * time.Millisecond
+
+// logTruncatedMark 追加在被截断的日志行末尾
+const logTruncatedMark = " …(truncated)"
+
 type DockerLogsPage struct {
 	*BaseFlex
-	app      *App            // 应用实例
-	logsView *tview.TextView // 日志视图
+	app         *App              // 应用实例
+	filterFlex  *tview.Flex       // 搜索框布局
+	filterInput *tview.InputField // 搜索的正则表达式
+	logsView    *tview.TextView   // 日志视图
+	cancelFn    context.CancelFunc
+
+	mx         sync.Mutex
+	lines      []*docker_drivers.LogLine // 日志缓冲，最多保留 maxLines 行
+	pending    []*docker_drivers.LogLine // 尚未写入界面的日志
+	maxLines   int
+	opts       docker_drivers.LogOptions
+	pattern    *regexp.Regexp // 搜索的正则，匹配内容高亮显示
+	matchOnly  bool           // 只显示匹配的行
+	showTime   bool           // 显示时间戳
+	paused     bool           // 暂停跟随，新日志只缓存不显示
+	autoscroll bool           // 新日志写入后滚动到底部
+	wrap       bool           // 自动换行
 
 	containerId   string // 当前选中的容器ID
 	containerName string // 当前选中的容器名称
@@ -21,35 +54,282 @@ type DockerLogsPage struct {
 
 func (_this *DockerLogsPage) bindKeys() {
 	_this.Actions().Bulk(ui.KeyMap{
-		ui.KeyF: ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeyF:         ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeySlash:     ui.NewKeyAction("Search", _this.toggleSearch, true),
+		ui.KeyM:         ui.NewKeyAction("Matches Only", _this.toggleMatchOnly, true),
+		ui.KeyT:         ui.NewKeyAction("Timestamps", _this.toggleTimestamps, true),
+		ui.KeyP:         ui.NewKeyAction("Pause/Follow", _this.togglePause, true),
+		ui.KeyW:         ui.NewKeyAction("Wrap", _this.toggleWrap, true),
+		ui.KeyO:         ui.NewKeyAction("Tail/Since", _this.changeOptions, true),
+		tcell.KeyCtrlS:  ui.NewKeyAction("Save", _this.saveLogs, true),
+		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
 	})
 }
 
-func (_this *DockerLogsPage) showLogsForSelectedContainer(containerID string) {
-	reader, err := docker_drivers.ContainerLogs(containerID)
-	if err != nil {
-		slog.Error("Failed to get logs for container", "containerID", containerID, "error", err)
+// toggleSearch 切换焦点到搜索框
+func (_this *DockerLogsPage) toggleSearch(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.app.UI.SetFocus(_this.filterInput)
+	return nil
+}
+
+// toggleMatchOnly 切换是否只显示匹配搜索的行
+func (_this *DockerLogsPage) toggleMatchOnly(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	if _this.pattern == nil {
+		_this.app.UI.Flash().Warn("Press / to enter a search pattern first")
+		return nil
+	}
+	_this.matchOnly = !_this.matchOnly
+	_this._render()
+	return nil
+}
+
+func (_this *DockerLogsPage) toggleTimestamps(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.showTime = !_this.showTime
+	_this._render()
+	return nil
+}
+
+// togglePause 暂停时新日志只缓存，恢复后一次性显示
+func (_this *DockerLogsPage) togglePause(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.mx.Lock()
+	_this.paused = !_this.paused
+	_this.mx.Unlock()
+	if !_this.paused {
+		_this._render()
+	}
+	_this._updateTitle()
+	return nil
+}
+
+func (_this *DockerLogsPage) toggleWrap(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	_this.wrap = !_this.wrap
+	_this.logsView.SetWrap(_this.wrap)
+	_this.logsView.SetWordWrap(_this.wrap)
+	return nil
+}
+
+// changeOptions 修改 tail/since 后重新读取日志
+func (_this *DockerLogsPage) changeOptions(evt *tcell.EventKey) *tcell.EventKey {
+	if ui.IsInputPrimitive(appUiInstance.GetFocus()) {
+		return evt
+	}
+	tail, since := "", ""
+	if _this.opts.Tail > 0 {
+		tail = strconv.FormatInt(_this.opts.Tail, 10)
+	}
+	if _this.opts.Since > 0 {
+		since = _this.opts.Since.String()
+	}
+	dialog.ShowDockerLogOptions(&config.Dialog{}, _this.app.Content.Pages, &dialog.DockerLogOptionsOpts{
+		Tail:  tail,
+		Since: since,
+		Ack: func(tail, since string) bool {
+			opts := _this.opts
+			opts.Tail, opts.Since = 0, 0
+			if tail != "" && tail != "all" {
+				n, err := strconv.ParseInt(tail, 10, 64)
+				if err != nil || n < 0 {
+					_this.app.UI.Flash().Err(fmt.Errorf("invalid tail %q, expected a number of lines", tail))
+					return false
+				}
+				opts.Tail = n
+			}
+			if since != "" {
+				d, err := time.ParseDuration(since)
+				if err != nil || d < 0 {
+					_this.app.UI.Flash().Err(fmt.Errorf("invalid since %q, expected a duration like 5m", since))
+					return false
+				}
+				opts.Since = d
+			}
+			_this.opts = opts
+			_this._startStream()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.logsView)
+		},
+	})
+	return nil
+}
+
+// saveLogs 将缓冲中的日志保存到截图目录
+func (_this *DockerLogsPage) saveLogs(evt *tcell.EventKey) *tcell.EventKey {
+	dir := _this.app.UI.Config.LXZ.ScreenDumpDir
+	if dir == "" {
+		dir = config.AppDumpsDir
+	}
+	name := fmt.Sprintf("%s-%s.log", _this.containerName, time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+
+	_this.mx.Lock()
+	var b strings.Builder
+	for _, line := range _this.lines {
+		if !line.Time.IsZero() && _this.showTime {
+			b.WriteString(line.Time.Format(time.RFC3339Nano))
+			b.WriteString(" ")
+		}
+		b.WriteString(line.Text)
+		if line.Truncated {
+			b.WriteString(logTruncatedMark)
+		}
+		b.WriteString("\n")
+	}
+	_this.mx.Unlock()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to create directory %s: %w", dir, err))
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to save logs: %w", err))
+		return nil
+	}
+	_this.app.UI.Flash().Info(fmt.Sprintf("Logs saved to %s", path))
+	return nil
+}
+
+// _formatLine 格式化一行日志，不满足过滤条件时返回 false
+func (_this *DockerLogsPage) _formatLine(line *docker_drivers.LogLine) (string, bool) {
+	if _this.matchOnly && _this.pattern != nil && !_this.pattern.MatchString(line.Text) {
+		return "", false
+	}
+	var b strings.Builder
+	if _this.showTime && !line.Time.IsZero() {
+		b.WriteString("[gray]")
+		b.WriteString(line.Time.Local().Format("2006-01-02 15:04:05.000"))
+		b.WriteString("[-] ")
+	}
+	restore := "[-:-]"
+	if line.Stderr {
+		restore = "[red:-]"
+		b.WriteString(restore)
+	}
+	b.WriteString(render.HighlightMatches(line.Text, _this.pattern, restore))
+	if line.Truncated {
+		b.WriteString("[yellow]" + logTruncatedMark + restore)
+	}
+	if line.Stderr {
+		b.WriteString("[-]")
+	}
+	return b.String(), true
+}
+
+// _render 按当前的显示选项重新渲染全部缓冲的日志
+func (_this *DockerLogsPage) _render() {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	_this.pending = nil
+	var b strings.Builder
+	for _, line := range _this.lines {
+		if text, ok := _this._formatLine(line); ok {
+			b.WriteString(text)
+			b.WriteString("\n")
+		}
+	}
+	_this.logsView.SetText(b.String())
+	if _this.autoscroll && !_this.paused {
+		_this.logsView.ScrollToEnd()
+	}
+}
+
+// _flush 将新日志追加到界面，需在 UI 线程调用
+func (_this *DockerLogsPage) _flush() {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	if _this.paused || len(_this.pending) == 0 {
 		return
 	}
+	for _, line := range _this.pending {
+		if text, ok := _this._formatLine(line); ok {
+			_, _ = fmt.Fprintln(_this.logsView, text)
+		}
+	}
+	_this.pending = nil
+	if _this.autoscroll {
+		_this.logsView.ScrollToEnd()
+	}
+}
 
+// _append 缓存读取到的日志，超出缓冲大小时丢弃最早的日志
+func (_this *DockerLogsPage) _append(line *docker_drivers.LogLine) {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	_this.lines = append(_this.lines, line)
+	if len(_this.lines) > _this.maxLines {
+		_this.lines = _this.lines[len(_this.lines)-_this.maxLines:]
+	}
+	_this.pending = append(_this.pending, line)
+	if len(_this.pending) > _this.maxLines {
+		_this.pending = _this.pending[len(_this.pending)-_this.maxLines:]
+	}
+}
+
+// _startStream 按当前 tail/since 重新读取日志
+func (_this *DockerLogsPage) _startStream() {
+	_this.Stop()
+	_this.mx.Lock()
+	_this.lines, _this.pending = nil, nil
+	_this.mx.Unlock()
+	_this.logsView.Clear()
+	_this._updateTitle()
+
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	go func() {
+		err := docker_drivers.StreamContainerLogs(ctx, _this.containerId, _this.opts, _this._append)
+		if err != nil {
+			slog.Error("Failed to get logs for container", "containerID", _this.containerId, "error", err)
+			_this.app.UI.QueueUpdateDraw(func() {
+				_this.app.UI.Flash().Err(err)
+			})
+		}
+	}()
 	go func() {
-		defer func() {
-			_ = reader.Close()
-		}()
-		buf := make([]byte, 4096)
+		ticker := time.NewTicker(dockerLogsFlushInterval)
+		defer ticker.Stop()
 		for {
-			n, err := reader.Read(buf)
-			slog.Info("Reading logs", "containerID", containerID, "bytesRead", n, "error", err)
-			if n > 0 {
-				_, _ = fmt.Fprintf(_this.logsView, "%s", string(buf[:n]))
-			}
-			if err != nil {
-				break
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_this.app.UI.QueueUpdateDraw(_this._flush)
 			}
 		}
 	}()
 }
 
+// _updateTitle 在标题中展示当前的读取选项
+func (_this *DockerLogsPage) _updateTitle() {
+	tail, since := "all", "-"
+	if _this.opts.Tail > 0 {
+		tail = strconv.FormatInt(_this.opts.Tail, 10)
+	}
+	if _this.opts.Since > 0 {
+		since = _this.opts.Since.String()
+	}
+	state := "following"
+	if _this.paused {
+		state = "paused"
+	}
+	_this.SetTitle(fmt.Sprintf(" %s [tail:%s since:%s %s] ", _this.containerName, tail, since, state))
+}
+
 func (_this *DockerLogsPage) Init(ctx context.Context) error {
 	slog.Info(
 		"Initializing DockerLogsPage",
@@ -60,21 +340,71 @@ func (_this *DockerLogsPage) Init(ctx context.Context) error {
 	)
 	_this.bindKeys()
 	_this.SetInputCapture(_this.Keyboard)
+	_this.SetDirection(tview.FlexRow)
+
+	logger := _this.app.UI.Config.LXZ.Logger.Validate()
+	_this.maxLines = logger.BufferSize
+	_this.opts = docker_drivers.LogOptions{Tail: logger.TailCount, Follow: true}
+	if logger.SinceSeconds > 0 {
+		_this.opts.Since = time.Duration(logger.SinceSeconds) * time.Second
+	}
+	_this.showTime = logger.ShowTime
+	_this.autoscroll = !logger.DisableAutoscroll
+	_this.wrap = logger.TextWrap
+
+	// 初始化搜索框
+	_this.filterFlex = tview.NewFlex()
+	_this.filterFlex.SetDirection(tview.FlexColumn)
+	_this.filterFlex.SetBorder(true)
+	_this.filterFlex.SetBorderPadding(0, 0, 1, 1)
+	filterLabel := tview.NewTextView()
+	filterLabel.SetText("Search: ")
+	filterLabel.SetTextAlign(tview.AlignCenter)
+	filterLabel.SetTextColor(tcell.ColorGreen)
+	_this.filterFlex.AddItem(filterLabel, 8, 1, false)
+
+	_this.filterInput = tview.NewInputField()
+	_this.filterInput.SetPlaceholder("Enter a regular expression to highlight, M to show matching lines only")
+	_this.filterInput.SetFieldBackgroundColor(tcell.ColorBlack)
+	_this.filterInput.SetFieldTextColor(tcell.ColorRed)
+	_this.filterInput.SetFocusFunc(func() {
+		_this.filterFlex.SetBorderColor(base.ActiveBorderColor)
+	})
+	_this.filterInput.SetBlurFunc(func() {
+		_this.filterFlex.SetBorderColor(base.InactiveBorderColor)
+	})
+	_this.filterInput.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			var pattern *regexp.Regexp
+			if text := _this.filterInput.GetText(); text != "" {
+				var err error
+				if pattern, err = regexp.Compile(text); err != nil {
+					_this.app.UI.Flash().Err(fmt.Errorf("invalid regular expression: %w", err))
+					return
+				}
+			}
+			_this.pattern = pattern
+			if pattern == nil {
+				_this.matchOnly = false
+			}
+			_this._render()
+			_this.app.UI.SetFocus(_this.logsView)
+		case tcell.KeyEscape:
+			_this.app.UI.SetFocus(_this.logsView)
+		}
+	})
+	_this.filterFlex.AddItem(_this.filterInput, 0, 5, true)
+	_this.AddItem(_this.filterFlex, 3, 0, false)
 
 	logsView := tview.NewTextView()
 	logsView.SetDynamicColors(true).
-		SetWrap(false).
 		SetRegions(true).
 		SetScrollable(true).
+		SetMaxLines(_this.maxLines).
 		SetBorder(false)
-	logsView.SetWordWrap(true)
-	logsView.SetWrap(true)
-	logsView.SetChangedFunc(func() {
-		_this.app.UI.QueueUpdateDraw(func() {
-			logsView.ScrollToEnd() // 👈 自动滚动到最后
-		})
-	})
-
+	logsView.SetWordWrap(_this.wrap)
+	logsView.SetWrap(_this.wrap)
 	_this.logsView = logsView
 
 	_this.
@@ -90,11 +420,16 @@ func (_this *DockerLogsPage) Start() {
 		"containerName",
 		_this.containerName,
 	)
-	_this.showLogsForSelectedContainer(_this.containerId)
-	_this.app.UI.SetFocus(_this)
+	_this._startStream()
+	_this.app.UI.SetFocus(_this.logsView)
 }
 
-func (_this *DockerLogsPage) Stop() {}
+func (_this *DockerLogsPage) Stop() {
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
+}
 
 func NewDockerLogsPage(app *App, containerId string, containerName string) *DockerLogsPage {
 	page := &DockerLogsPage{
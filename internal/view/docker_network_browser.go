@@ -0,0 +1,273 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 11:20
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/tview"
+)
+
+var dockerNetworkHeaders = []string{"Name", "Network ID", "Driver", "Scope", "Subnet", "Created", "Containers"}
+
+type DockerNetworkBrowser struct {
+	*BaseFlex
+	app             *App
+	networkTableUI  *tview.Table
+	selectedNetwork *docker_drivers.NetworkData // 当前选中的网络
+}
+
+func (_this *DockerNetworkBrowser) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF:        ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeyI:        ui.NewKeyAction("Detail Info", _this.showDetail, true),
+		ui.KeyC:        ui.NewKeyAction("Connect", _this.connectContainer, true),
+		ui.KeyD:        ui.NewKeyAction("Disconnect", _this.disconnectContainer, true),
+		ui.KeyX:        ui.NewKeyAction("Prune Unused", _this.pruneNetworks, true),
+		tcell.KeyCtrlN: ui.NewKeyAction("New Network", _this.createNetwork, true),
+		tcell.KeyCtrlR: ui.NewKeyAction("Refresh", _this.refresh, true),
+		tcell.KeyCtrlD: ui.NewKeyAction("Delete", _this.removeNetwork, true),
+	})
+}
+
+// showDetail 查看网络详情
+func (_this *DockerNetworkBrowser) showDetail(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedNetwork == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a network first"))
+		return nil
+	}
+	n := _this.selectedNetwork
+	if err := _this.app.inject(NewDockerInspectView(_this.app, "network", n.ID, n.Name), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker inspect view: %w", err))
+	}
+	return nil
+}
+
+// createNetwork 创建网络
+func (_this *DockerNetworkBrowser) createNetwork(evt *tcell.EventKey) *tcell.EventKey {
+	dialog.ShowCreateDockerNetwork(&config.Dialog{}, _this.app.Content.Pages, &dialog.CreateDockerNetworkOpts{
+		Ack: func(name, driver string, internal bool) bool {
+			if err := docker_drivers.CreateNetwork(name, driver, internal); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Network %s created successfully", name))
+			_this._refreshData()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.networkTableUI)
+		},
+	})
+	return nil
+}
+
+// removeNetwork 删除选中的网络
+func (_this *DockerNetworkBrowser) removeNetwork(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedNetwork == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a network first"))
+		return nil
+	}
+	n := _this.selectedNetwork
+	msg := fmt.Sprintf("Delete network %s?", n.Name)
+	if len(n.Containers) > 0 {
+		msg += fmt.Sprintf("\nConnected: %s", strings.Join(n.Containers, ", "))
+	}
+	dialog.ShowDelete(&config.Dialog{}, _this.app.Content.Pages, msg, func(force bool) {
+		if err := docker_drivers.RemoveNetwork(n.ID); err != nil {
+			_this.app.UI.Flash().Err(err)
+			return
+		}
+		_this.app.UI.Flash().Info(fmt.Sprintf("Network %s deleted successfully", n.Name))
+		_this._refreshData()
+	}, func() {
+		_this.app.UI.SetFocus(_this.networkTableUI)
+	})
+	return nil
+}
+
+// pruneNetworks 清理未使用的网络
+func (_this *DockerNetworkBrowser) pruneNetworks(evt *tcell.EventKey) *tcell.EventKey {
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to prune unused networks?",
+		"Custom networks not used by any container will be removed.",
+		func(force bool) {
+			deleted, err := docker_drivers.PruneNetworks()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				return
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Pruned %d networks", len(deleted)))
+			_this._refreshData()
+		},
+		func() {
+			_this.app.UI.SetFocus(_this.networkTableUI)
+		})
+	return nil
+}
+
+// connectContainer 将容器接入选中的网络
+func (_this *DockerNetworkBrowser) connectContainer(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedNetwork == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a network first"))
+		return nil
+	}
+	n := _this.selectedNetwork
+	names, err := docker_drivers.ListContainerNames()
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return nil
+	}
+	candidates := make([]string, 0, len(names))
+	for _, name := range names {
+		if !helper.Contains(n.Containers, name) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		_this.app.UI.Flash().Warn("All containers are already connected to this network.")
+		return nil
+	}
+	dialog.ShowDockerNetworkContainer(&config.Dialog{}, _this.app.Content.Pages, &dialog.DockerNetworkContainerOpts{
+		Title:      "Connect Container",
+		Message:    fmt.Sprintf("Network: %s", n.Name),
+		Containers: candidates,
+		Ack: func(container string, force bool) bool {
+			if err := docker_drivers.ConnectNetwork(n.ID, container); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Container %s connected to %s", container, n.Name))
+			_this._refreshData()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.networkTableUI)
+		},
+	})
+	return nil
+}
+
+// disconnectContainer 将容器从选中的网络断开
+func (_this *DockerNetworkBrowser) disconnectContainer(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedNetwork == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a network first"))
+		return nil
+	}
+	n := _this.selectedNetwork
+	if len(n.Containers) == 0 {
+		_this.app.UI.Flash().Warn("No container is connected to this network.")
+		return nil
+	}
+	dialog.ShowDockerNetworkContainer(&config.Dialog{}, _this.app.Content.Pages, &dialog.DockerNetworkContainerOpts{
+		Title:      "Disconnect Container",
+		Message:    fmt.Sprintf("Network: %s", n.Name),
+		Containers: n.Containers,
+		Force:      true,
+		Ack: func(container string, force bool) bool {
+			if err := docker_drivers.DisconnectNetwork(n.ID, container, force); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Container %s disconnected from %s", container, n.Name))
+			_this._refreshData()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.networkTableUI)
+		},
+	})
+	return nil
+}
+
+func (_this *DockerNetworkBrowser) refresh(evt *tcell.EventKey) *tcell.EventKey {
+	_this._refreshData()
+	return nil
+}
+
+func (_this *DockerNetworkBrowser) _refreshData() {
+	networks, err := docker_drivers.ListNetworks()
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	// 刷新后保持原来选中的网络
+	selectedID := ""
+	if _this.selectedNetwork != nil {
+		selectedID = _this.selectedNetwork.ID
+	}
+	resetDockerTable(_this.networkTableUI, dockerNetworkHeaders...)
+	_this.selectedNetwork = nil
+	selectedRow := 1
+	for i, n := range networks {
+		if i == 0 || n.ID == selectedID {
+			_this.selectedNetwork = n
+			selectedRow = i + 1
+		}
+		containers := "-"
+		if len(n.Containers) > 0 {
+			containers = strings.Join(n.Containers, ", ")
+		}
+		name := n.Name
+		if n.Internal {
+			name += " (internal)"
+		}
+		_this.networkTableUI.SetCell(i+1, 0, dockerCell(name, n))
+		_this.networkTableUI.SetCell(i+1, 1, dockerCell(shortDockerID(n.ID), n))
+		_this.networkTableUI.SetCell(i+1, 2, dockerCell(n.Driver, n))
+		_this.networkTableUI.SetCell(i+1, 3, dockerCell(n.Scope, n))
+		_this.networkTableUI.SetCell(i+1, 4, dockerCell(helper.If(n.Subnet == "", "-", n.Subnet), n))
+		_this.networkTableUI.SetCell(i+1, 5, dockerCell(helper.TimeFormat(n.Created.Unix()), n))
+		_this.networkTableUI.SetCell(i+1, 6, dockerCell(containers, n))
+	}
+	_this.networkTableUI.SetTitle(fmt.Sprintf("🌐 Networks (%d)", len(networks)))
+	_this.networkTableUI.Select(selectedRow, 0)
+}
+
+func (_this *DockerNetworkBrowser) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.networkTableUI = newDockerTable("🌐 Networks", dockerNetworkHeaders...)
+	// 设置表格的选择模式
+	_this.networkTableUI.SetSelectionChangedFunc(func(row, column int) {
+		if row < 1 || row >= _this.networkTableUI.GetRowCount() {
+			return
+		}
+		if n, ok := _this.networkTableUI.GetCell(row, 0).GetReference().(*docker_drivers.NetworkData); ok {
+			_this.selectedNetwork = n
+		}
+	})
+
+	_this.AddItem(_this.networkTableUI, 0, 1, true)
+
+	return nil
+}
+
+func (_this *DockerNetworkBrowser) Start() {
+	_this._refreshData()
+
+	_this.app.UI.SetFocus(_this.networkTableUI)
+}
+
+func (_this *DockerNetworkBrowser) Stop() {}
+
+func NewDockerNetworkBrowser(app *App) *DockerNetworkBrowser {
+	var name = "Docker Networks"
+	f := &DockerNetworkBrowser{
+		BaseFlex: NewBaseFlex(name),
+		app:      app,
+	}
+	return f
+}
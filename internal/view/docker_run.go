@@ -0,0 +1,113 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/27 11:00
+ */
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/slogs"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// dockerDefaultNetwork 网络选项中表示使用 Docker 默认网络的选项
+const dockerDefaultNetwork = "default"
+
+// showDockerRun 打开运行容器对话框，image 为空时手动输入镜像，成功运行后记录为模板并回调 done
+func showDockerRun(app *App, image string, focus tview.Primitive, done func(id string)) {
+	networks := []string{dockerDefaultNetwork}
+	if list, err := docker_drivers.ListNetworks(); err != nil {
+		slog.Warn("Failed to list docker networks", slogs.Error, err)
+	} else {
+		for _, n := range list {
+			networks = append(networks, n.Name)
+		}
+	}
+	dockerConfig, err := loadDockerConfiguration()
+	if err != nil {
+		slog.Error("Failed to load docker configuration", slogs.Error, err)
+	}
+
+	dialog.ShowDockerRun(&config.Dialog{}, app.Content.Pages, &dialog.DockerRunOpts{
+		Image:     image,
+		Networks:  networks,
+		Templates: dockerConfig.RunTemplates,
+		Ack: func(t *config.DockerRunTemplate) bool {
+			if t.Image == "" {
+				app.UI.Flash().Err(fmt.Errorf("image is required"))
+				return false
+			}
+			command, err := docker_drivers.SplitCommand(t.Command)
+			if err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			if _, _, err = docker_drivers.ParsePortMappings(t.Ports); err != nil {
+				app.UI.Flash().Err(err)
+				return false
+			}
+			opts := &docker_drivers.RunOptions{
+				Image:         t.Image,
+				Name:          t.Name,
+				Command:       command,
+				Env:           t.Env,
+				Ports:         t.Ports,
+				Volumes:       t.Volumes,
+				RestartPolicy: t.RestartPolicy,
+				AutoRemove:    t.AutoRemove,
+			}
+			if t.Network != dockerDefaultNetwork {
+				opts.Network = t.Network
+			}
+			_runDockerContainer(app, opts, t, dockerConfig, focus, done)
+			return true
+		},
+		Cancel: func() {
+			app.UI.SetFocus(focus)
+		},
+	})
+}
+
+// _runDockerContainer 异步创建并启动容器，创建成功后保存运行参数
+func _runDockerContainer(
+	app *App,
+	opts *docker_drivers.RunOptions,
+	t *config.DockerRunTemplate,
+	dockerConfig *config.DockerConfig,
+	focus tview.Primitive,
+	done func(id string),
+) {
+	loading := dialog.ShowLoadingDialog(
+		app.Content.Pages,
+		fmt.Sprintf("⏳ Running container from %s...", opts.Image),
+		app.UI.ForceDraw,
+	)
+	go func() {
+		id, err := docker_drivers.RunContainer(opts)
+		// 容器已创建时参数是有效的，即使启动失败也记录下来便于修改后重试
+		if id != "" {
+			dockerConfig.AddRunTemplate(t)
+			if err := dockerConfig.Save(true); err != nil {
+				slog.Error("Failed to save docker run template", slogs.Error, err)
+			}
+		}
+		app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			if err != nil {
+				app.UI.Flash().Err(err)
+			} else {
+				app.UI.Flash().Info(fmt.Sprintf("Container %s started from %s", shortDockerID(id), opts.Image))
+			}
+			app.UI.SetFocus(focus)
+			if id != "" && done != nil {
+				done(id)
+			}
+		})
+	}()
+}
@@ -0,0 +1,159 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 16:50
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+)
+
+// dockerStatsHistorySize 每个容器保留的历史采样数量，用于绘制趋势图
+const dockerStatsHistorySize = 120
+
+// dockerStatsCollector 为运行中的容器持续采集资源统计，并保留最近的历史采样
+type dockerStatsCollector struct {
+	mx      sync.RWMutex
+	streams map[string]context.CancelFunc // 容器ID -> 停止采集
+	history map[string][]*docker_drivers.ContainerStatsData
+}
+
+func newDockerStatsCollector() *dockerStatsCollector {
+	return &dockerStatsCollector{
+		streams: make(map[string]context.CancelFunc),
+		history: make(map[string][]*docker_drivers.ContainerStatsData),
+	}
+}
+
+// Seed 预置容器的历史采样，详情页沿用列表页已采集的数据
+func (_this *dockerStatsCollector) Seed(containerID string, history []*docker_drivers.ContainerStatsData) {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	_this.history[containerID] = history
+}
+
+// Track 采集指定容器的统计，不在列表中的容器停止采集
+func (_this *dockerStatsCollector) Track(containerIDs []string) {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	keep := make(map[string]bool, len(containerIDs))
+	for _, id := range containerIDs {
+		keep[id] = true
+		if _, ok := _this.streams[id]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		_this.streams[id] = cancel
+		go _this.stream(ctx, id)
+	}
+	for id, cancel := range _this.streams {
+		if !keep[id] {
+			cancel()
+			delete(_this.streams, id)
+			delete(_this.history, id)
+		}
+	}
+}
+
+func (_this *dockerStatsCollector) stream(ctx context.Context, containerID string) {
+	err := docker_drivers.StreamContainerStats(ctx, containerID, func(stats *docker_drivers.ContainerStatsData) {
+		_this.mx.Lock()
+		defer _this.mx.Unlock()
+		history := append(_this.history[containerID], stats)
+		if len(history) > dockerStatsHistorySize {
+			history = history[len(history)-dockerStatsHistorySize:]
+		}
+		_this.history[containerID] = history
+	})
+	if err != nil {
+		slog.Warn("Container stats stream stopped", "containerID", containerID, "error", err)
+	}
+	// 容器停止后流会结束，移除记录以便重新启动时再次采集
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	if ctx.Err() == nil {
+		delete(_this.streams, containerID)
+	}
+}
+
+// Latest 获取容器最近一次采样
+func (_this *dockerStatsCollector) Latest(containerID string) *docker_drivers.ContainerStatsData {
+	_this.mx.RLock()
+	defer _this.mx.RUnlock()
+	history := _this.history[containerID]
+	if len(history) == 0 {
+		return nil
+	}
+	return history[len(history)-1]
+}
+
+// History 获取容器历史采样的副本
+func (_this *dockerStatsCollector) History(containerID string) []*docker_drivers.ContainerStatsData {
+	_this.mx.RLock()
+	defer _this.mx.RUnlock()
+	return append([]*docker_drivers.ContainerStatsData(nil), _this.history[containerID]...)
+}
+
+// Stop 停止所有采集，可重复调用
+func (_this *dockerStatsCollector) Stop() {
+	_this.mx.Lock()
+	defer _this.mx.Unlock()
+	for id, cancel := range _this.streams {
+		cancel()
+		delete(_this.streams, id)
+	}
+}
+
+// dockerRefreshInterval 资源统计的界面刷新间隔，取自 LXZ.RefreshRate
+func dockerRefreshInterval(app *App) time.Duration {
+	rate := app.UI.Config.LXZ.RefreshRate
+	if rate <= 0 {
+		rate = 2
+	}
+	return time.Duration(rate) * time.Second
+}
+
+func formatCPU(stats *docker_drivers.ContainerStatsData) string {
+	if stats == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", stats.CPUPercent)
+}
+
+func formatMemory(stats *docker_drivers.ContainerStatsData) string {
+	if stats == nil {
+		return "-"
+	}
+	if stats.MemoryLimit == 0 {
+		return units.BytesSize(float64(stats.MemoryUsage))
+	}
+	return fmt.Sprintf("%s / %s",
+		units.BytesSize(float64(stats.MemoryUsage)),
+		units.BytesSize(float64(stats.MemoryLimit)))
+}
+
+func formatNetIO(stats *docker_drivers.ContainerStatsData) string {
+	if stats == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s / %s",
+		units.HumanSize(float64(stats.NetRx)),
+		units.HumanSize(float64(stats.NetTx)))
+}
+
+func formatBlockIO(stats *docker_drivers.ContainerStatsData) string {
+	if stats == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s / %s",
+		units.HumanSize(float64(stats.BlockRead)),
+		units.HumanSize(float64(stats.BlockWrite)))
+}
@@ -0,0 +1,166 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 17:40
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/render"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// dockerStatsLabelWidth 趋势图左侧标签和数值所占的宽度
+const dockerStatsLabelWidth = 34
+
+type DockerStatsView struct {
+	*BaseFlex
+	app           *App
+	statsViewUI   *tview.TextView
+	stats         *dockerStatsCollector
+	cancelFn      context.CancelFunc // 停止定时刷新
+	containerId   string             // 容器ID
+	containerName string             // 容器名称
+}
+
+func (_this *DockerStatsView) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF: ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+	})
+}
+
+// _render 渲染当前值及历史趋势，网络和磁盘按相邻采样的差值展示速率
+func (_this *DockerStatsView) _render() {
+	history := _this.stats.History(_this.containerId)
+	if len(history) == 0 {
+		_this.statsViewUI.SetText("⏳ Waiting for stats, the container may not be running...")
+		return
+	}
+	_, _, width, _ := _this.statsViewUI.GetInnerRect()
+	width = max(width-dockerStatsLabelWidth, 10)
+
+	var cpu, mem, netRx, netTx, blkRead, blkWrite []float64
+	for i, s := range history {
+		cpu = append(cpu, s.CPUPercent)
+		mem = append(mem, float64(s.MemoryUsage))
+		if i == 0 {
+			continue
+		}
+		prev := history[i-1]
+		seconds := s.Read.Sub(prev.Read).Seconds()
+		if seconds <= 0 {
+			seconds = 1
+		}
+		netRx = append(netRx, rate(prev.NetRx, s.NetRx, seconds))
+		netTx = append(netTx, rate(prev.NetTx, s.NetTx, seconds))
+		blkRead = append(blkRead, rate(prev.BlockRead, s.BlockRead, seconds))
+		blkWrite = append(blkWrite, rate(prev.BlockWrite, s.BlockWrite, seconds))
+	}
+	latest := history[len(history)-1]
+
+	var b strings.Builder
+	line := func(label, value string, values []float64, ceiling float64, color string) {
+		_, _ = fmt.Fprintf(&b, "[yellow]%-12s[white]%-22s[%s]%s[white]\n\n",
+			label, value, color, render.Sparkline(values, ceiling, width))
+	}
+	line("CPU", formatCPU(latest), cpu, 0, "green")
+	memValue := units.BytesSize(float64(latest.MemoryUsage))
+	if latest.MemoryLimit > 0 {
+		memValue = fmt.Sprintf("%s (%.1f%%)", memValue, latest.MemoryPercent)
+	}
+	line("Memory", memValue, mem, float64(latest.MemoryLimit), "aqua")
+	line("Net RX", lastRate(netRx), netRx, 0, "blue")
+	line("Net TX", lastRate(netTx), netTx, 0, "blue")
+	line("Block Read", lastRate(blkRead), blkRead, 0, "purple")
+	line("Block Write", lastRate(blkWrite), blkWrite, 0, "purple")
+	_, _ = fmt.Fprintf(&b, "[yellow]%-12s[white]%d\n\n", "PIDs", latest.PIDs)
+	_, _ = fmt.Fprintf(&b, "[yellow]%-12s[white]%s\n", "Net I/O", formatNetIO(latest))
+	_, _ = fmt.Fprintf(&b, "[yellow]%-12s[white]%s\n", "Block I/O", formatBlockIO(latest))
+	_, _ = fmt.Fprintf(&b, "[yellow]%-12s[white]%s (%d samples)\n", "Updated",
+		latest.Read.Local().Format(time.TimeOnly), len(history))
+	_this.statsViewUI.SetText(b.String())
+}
+
+// rate 计算两次采样之间的每秒增量，计数器重置时按 0 处理
+func rate(prev, cur uint64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+func lastRate(values []float64) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return units.HumanSize(values[len(values)-1]) + "/s"
+}
+
+func (_this *DockerStatsView) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.statsViewUI = tview.NewTextView()
+	_this.statsViewUI.SetDynamicColors(true)
+	_this.statsViewUI.SetBorder(false)
+	_this.statsViewUI.SetWrap(false)
+	_this.statsViewUI.SetBorderPadding(1, 1, 2, 2)
+	_this.AddItem(_this.statsViewUI, 0, 1, true)
+	return nil
+}
+
+func (_this *DockerStatsView) Start() {
+	_this.Stop()
+	_this.stats.Track([]string{_this.containerId})
+	_this._render()
+
+	var ctx context.Context
+	ctx, _this.cancelFn = context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(dockerRefreshInterval(_this.app))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_this.app.UI.QueueUpdateDraw(_this._render)
+			}
+		}
+	}()
+
+	_this.app.UI.SetFocus(_this.statsViewUI)
+}
+
+func (_this *DockerStatsView) Stop() {
+	if _this.cancelFn != nil {
+		_this.cancelFn()
+		_this.cancelFn = nil
+	}
+	_this.stats.Stop()
+}
+
+func NewDockerStatsView(
+	app *App,
+	containerId, containerName string,
+	history []*docker_drivers.ContainerStatsData,
+) *DockerStatsView {
+	var name = fmt.Sprintf("Stats: %s", containerName)
+	stats := newDockerStatsCollector()
+	stats.Seed(containerId, history)
+	f := &DockerStatsView{
+		BaseFlex:      NewBaseFlex(name),
+		app:           app,
+		stats:         stats,
+		containerId:   containerId,
+		containerName: containerName,
+	}
+	return f
+}
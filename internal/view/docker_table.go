@@ -0,0 +1,56 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/22 15:00
+ */
+
+package view
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/tview"
+)
+
+// newDockerTable 创建 Docker 资源列表表格，首行为表头
+func newDockerTable(title string, headers ...string) *tview.Table {
+	table := tview.NewTable()
+	table.SetBorder(false)
+	table.SetBorders(false)
+	table.SetTitle(title)
+	table.SetBorderPadding(1, 1, 2, 2)
+	table.SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	resetDockerTable(table, headers...)
+	return table
+}
+
+// resetDockerTable 清空表格数据并重新设置表头
+func resetDockerTable(table *tview.Table, headers ...string) {
+	table.Clear()
+	for i, header := range headers {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1).
+			SetSelectable(false))
+	}
+}
+
+// dockerCell 表格数据单元格，reference 用于保存选中后需要的原始值
+func dockerCell(text string, reference any) *tview.TableCell {
+	return tview.NewTableCell(tview.Escape(text)).
+		SetReference(reference).
+		SetTextColor(tcell.ColorWhite).
+		SetAlign(tview.AlignLeft).
+		SetExpansion(1)
+}
+
+// shortDockerID 去掉 sha256: 前缀并截取前 12 位
+func shortDockerID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
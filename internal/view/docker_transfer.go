@@ -0,0 +1,104 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/25 11:30
+ */
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// transferFiles 在本机和容器之间上传或下载文件，容器路径格式为 容器名:路径
+func (_this *DockerBrowser) transferFiles(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedContainerID == "" {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a container first"))
+		return nil
+	}
+	dialog.ShowUploads(&config.Dialog{}, _this.app.Content.Pages, &dialog.TransferDialogOpts{
+		Containers: []string{_this.selectContainerName},
+		Pod:        _this.selectContainerName + ":",
+		Title:      "Transfer",
+		Message:    "Download Files\nContainer paths are written as container:/path",
+		Ack: func(args dialog.TransferArgs) bool {
+			remote, local := args.From, args.To
+			if !args.Download {
+				remote, local = args.To, args.From
+			}
+			container := strings.TrimSpace(args.CO)
+			// 容器路径可以带 容器名: 前缀，本机路径不解析前缀以兼容 Windows 盘符
+			if name, p, ok := strings.Cut(remote, ":"); ok && !strings.Contains(name, "/") {
+				if name != "" {
+					container = name
+				}
+				remote = p
+			}
+			remote, local = strings.TrimSpace(remote), strings.TrimSpace(local)
+			if container == "" || remote == "" || local == "" {
+				_this.app.UI.Flash().Err(fmt.Errorf("container, from and to are required"))
+				return false
+			}
+			args.CO = container
+			_this._transfer(args, remote, local)
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		},
+	})
+	return nil
+}
+
+// _transfer 异步执行复制并展示进度，失败时按 Retries 重试
+func (_this *DockerBrowser) _transfer(args dialog.TransferArgs, remote, local string) {
+	verb, desc := "Uploading", fmt.Sprintf("%s -> %s:%s", local, args.CO, remote)
+	if args.Download {
+		verb, desc = "Downloading", fmt.Sprintf("%s:%s -> %s", args.CO, remote, local)
+	}
+	loading := dialog.ShowLoadingDialog(
+		_this.app.Content.Pages,
+		fmt.Sprintf("⏳ %s %s...", verb, desc),
+		_this.app.UI.ForceDraw,
+	)
+	progress := func(copied, total int64) {
+		status := units.HumanSize(float64(copied))
+		if total > 0 {
+			status = fmt.Sprintf("%s / %s (%.0f%%)",
+				status, units.HumanSize(float64(total)), float64(copied)/float64(total)*100)
+		}
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.SetMessage(fmt.Sprintf("⏳ %s %s\n%s", verb, desc, status))
+		})
+	}
+	go func() {
+		var err error
+		for attempt := 0; attempt <= max(args.Retries, 0); attempt++ {
+			if args.Download {
+				err = docker_drivers.CopyFromContainer(args.CO, remote, local, !args.NoPreserve, progress)
+			} else {
+				err = docker_drivers.CopyToContainer(args.CO, local, remote, !args.NoPreserve, progress)
+			}
+			if err == nil {
+				break
+			}
+			slog.Warn("File transfer failed", "attempt", attempt+1, "desc", desc, "error", err)
+		}
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Transferred %s successfully", desc))
+			}
+			_this.app.UI.SetFocus(_this.containerTableUI)
+		})
+	}()
+}
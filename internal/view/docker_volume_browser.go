@@ -0,0 +1,208 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/23 14:05
+ */
+
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/docker_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/helper"
+	"github.com/liangzhaoliang95/lxz/internal/ui"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+	"github.com/liangzhaoliang95/tview"
+)
+
+var dockerVolumeHeaders = []string{"Name", "Driver", "Scope", "Mountpoint", "Created", "Containers"}
+
+type DockerVolumeBrowser struct {
+	*BaseFlex
+	app            *App
+	volumeTableUI  *tview.Table
+	selectedVolume *docker_drivers.VolumeData // 当前选中的数据卷
+}
+
+func (_this *DockerVolumeBrowser) bindKeys() {
+	_this.Actions().Bulk(ui.KeyMap{
+		ui.KeyF:        ui.NewKeyAction("FullScreen", _this.ToggleFullScreenCmd, true),
+		ui.KeyI:        ui.NewKeyAction("Detail Info", _this.showDetail, true),
+		ui.KeyX:        ui.NewKeyAction("Prune Unused", _this.pruneVolumes, true),
+		tcell.KeyCtrlN: ui.NewKeyAction("New Volume", _this.createVolume, true),
+		tcell.KeyCtrlR: ui.NewKeyAction("Refresh", _this.refresh, true),
+		tcell.KeyCtrlD: ui.NewKeyAction("Delete", _this.removeVolume, true),
+	})
+}
+
+// showDetail 查看数据卷详情
+func (_this *DockerVolumeBrowser) showDetail(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedVolume == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a volume first"))
+		return nil
+	}
+	v := _this.selectedVolume
+	if err := _this.app.inject(NewDockerInspectView(_this.app, "volume", v.Name, v.Name), false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject docker inspect view: %w", err))
+	}
+	return nil
+}
+
+// createVolume 创建数据卷
+func (_this *DockerVolumeBrowser) createVolume(evt *tcell.EventKey) *tcell.EventKey {
+	dialog.ShowCreateDockerVolume(&config.Dialog{}, _this.app.Content.Pages, &dialog.CreateDockerVolumeOpts{
+		Ack: func(name, driver string) bool {
+			created, err := docker_drivers.CreateVolume(name, driver)
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Volume %s created successfully", created))
+			_this._refreshData()
+			return true
+		},
+		Cancel: func() {
+			_this.app.UI.SetFocus(_this.volumeTableUI)
+		},
+	})
+	return nil
+}
+
+// removeVolume 删除选中的数据卷，勾选 Force 时强制删除
+func (_this *DockerVolumeBrowser) removeVolume(evt *tcell.EventKey) *tcell.EventKey {
+	if _this.selectedVolume == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("please select a volume first"))
+		return nil
+	}
+	v := _this.selectedVolume
+	msg := v.Name
+	if len(v.Containers) > 0 {
+		msg += fmt.Sprintf("\nMounted by: %s", strings.Join(v.Containers, ", "))
+	}
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to delete the volume?",
+		msg,
+		func(force bool) {
+			if err := docker_drivers.RemoveVolume(v.Name, force); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf("Volume %s deleted successfully", v.Name))
+			_this._refreshData()
+		},
+		func() {
+			_this.app.UI.SetFocus(_this.volumeTableUI)
+		})
+	return nil
+}
+
+// pruneVolumes 清理未使用的数据卷
+func (_this *DockerVolumeBrowser) pruneVolumes(evt *tcell.EventKey) *tcell.EventKey {
+	dialog.ShowConfirm(&config.Dialog{},
+		_this.app.Content.Pages,
+		"Are you sure you want to prune unused volumes?",
+		"Volumes not mounted by any container will be removed.",
+		func(force bool) {
+			count, reclaimed, err := docker_drivers.PruneVolumes()
+			if err != nil {
+				_this.app.UI.Flash().Err(err)
+				return
+			}
+			_this.app.UI.Flash().Info(fmt.Sprintf(
+				"Pruned %d volumes, reclaimed %s",
+				count,
+				units.HumanSize(float64(reclaimed)),
+			))
+			_this._refreshData()
+		},
+		func() {
+			_this.app.UI.SetFocus(_this.volumeTableUI)
+		})
+	return nil
+}
+
+func (_this *DockerVolumeBrowser) refresh(evt *tcell.EventKey) *tcell.EventKey {
+	_this._refreshData()
+	return nil
+}
+
+func (_this *DockerVolumeBrowser) _refreshData() {
+	volumes, err := docker_drivers.ListVolumes()
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	// 刷新后保持原来选中的数据卷
+	selectedName := ""
+	if _this.selectedVolume != nil {
+		selectedName = _this.selectedVolume.Name
+	}
+	resetDockerTable(_this.volumeTableUI, dockerVolumeHeaders...)
+	_this.selectedVolume = nil
+	selectedRow := 1
+	for i, v := range volumes {
+		if i == 0 || v.Name == selectedName {
+			_this.selectedVolume = v
+			selectedRow = i + 1
+		}
+		containers := "-"
+		if len(v.Containers) > 0 {
+			containers = strings.Join(v.Containers, ", ")
+		}
+		created := v.CreatedAt
+		if t, err := time.Parse(time.RFC3339, v.CreatedAt); err == nil {
+			created = helper.TimeFormat(t.Unix())
+		}
+		_this.volumeTableUI.SetCell(i+1, 0, dockerCell(v.Name, v))
+		_this.volumeTableUI.SetCell(i+1, 1, dockerCell(v.Driver, v))
+		_this.volumeTableUI.SetCell(i+1, 2, dockerCell(v.Scope, v))
+		_this.volumeTableUI.SetCell(i+1, 3, dockerCell(v.Mountpoint, v))
+		_this.volumeTableUI.SetCell(i+1, 4, dockerCell(created, v))
+		_this.volumeTableUI.SetCell(i+1, 5, dockerCell(containers, v))
+	}
+	_this.volumeTableUI.SetTitle(fmt.Sprintf("💾 Volumes (%d)", len(volumes)))
+	_this.volumeTableUI.Select(selectedRow, 0)
+}
+
+func (_this *DockerVolumeBrowser) Init(ctx context.Context) error {
+	_this.bindKeys()
+	_this.SetInputCapture(_this.Keyboard)
+	_this.volumeTableUI = newDockerTable("💾 Volumes", dockerVolumeHeaders...)
+	// 设置表格的选择模式
+	_this.volumeTableUI.SetSelectionChangedFunc(func(row, column int) {
+		if row < 1 || row >= _this.volumeTableUI.GetRowCount() {
+			return
+		}
+		if v, ok := _this.volumeTableUI.GetCell(row, 0).GetReference().(*docker_drivers.VolumeData); ok {
+			_this.selectedVolume = v
+		}
+	})
+
+	_this.AddItem(_this.volumeTableUI, 0, 1, true)
+
+	return nil
+}
+
+func (_this *DockerVolumeBrowser) Start() {
+	_this._refreshData()
+
+	_this.app.UI.SetFocus(_this.volumeTableUI)
+}
+
+func (_this *DockerVolumeBrowser) Stop() {}
+
+func NewDockerVolumeBrowser(app *App) *DockerVolumeBrowser {
+	var name = "Docker Volumes"
+	f := &DockerVolumeBrowser{
+		BaseFlex: NewBaseFlex(name),
+		app:      app,
+	}
+	return f
+}
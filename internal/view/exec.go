@@ -35,31 +35,6 @@ func (s shellOpts) String() string {
 	return fmt.Sprintf("%s %s", s.binary, strings.Join(s.args, " "))
 }
 
-func runDockerExec(a *App, opts *shellOpts) error {
-	bin, err := exec.LookPath("docker")
-	if errors.Is(err, exec.ErrDot) {
-		return fmt.Errorf("docker command must not be in the current working directory: %w", err)
-	}
-	if err != nil {
-		return fmt.Errorf("docker command is not in your path: %w", err)
-	}
-	opts.binary = bin
-
-	suspended, errChan, stChan := run(a, opts)
-	if !suspended {
-		return fmt.Errorf("unable to run command")
-	}
-	for v := range stChan {
-		slog.Debug("stdout", slogs.Line, v)
-	}
-	var errs error
-	for e := range errChan {
-		errs = errors.Join(errs, e)
-	}
-
-	return errs
-}
-
 func runK9sExec(a *App, opts *shellOpts) error {
 	bin, err := exec.LookPath("k9s")
 	if errors.Is(err, exec.ErrDot) {
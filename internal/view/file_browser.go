@@ -8,6 +8,7 @@ package view
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/exec"
@@ -22,6 +23,8 @@ import (
 	"github.com/liangzhaoliang95/tview"
 )
 
+const sqliteFileHeader = "SQLite format 3\x00"
+
 type FileBrowser struct {
 	*BaseFlex
 	app              *App
@@ -33,6 +36,7 @@ type FileBrowser struct {
 	lastFocusedAt    time.Time
 	debounceInterval time.Duration
 	stopDebounceCh   chan struct{}
+	pickFn           func(path string) // 不为空时用于选择文件
 }
 
 func (_this *FileBrowser) bindKeys() {
@@ -48,6 +52,9 @@ func (_this *FileBrowser) bindKeys() {
 		tcell.KeyLeft:   ui.NewKeyAction("Focus Change", _this.TabFocusChange, false),
 		tcell.KeyRight:  ui.NewKeyAction("Focus Change", _this.TabFocusChange, false),
 	})
+	if _this.pickFn != nil {
+		_this.Actions().Add(tcell.KeyEnter, ui.NewKeyAction("Select File", _this.TabFocusChange, true))
+	}
 }
 
 func (_this *FileBrowser) Init(ctx context.Context) error {
@@ -237,6 +244,18 @@ func (_this *FileBrowser) TabFocusChange(event *tcell.EventKey) *tcell.EventKey
 			return event
 		}
 
+		// 选择文件时返回上一页
+		if _this.pickFn != nil {
+			_this.pickFile(path)
+			return nil
+		}
+
+		// SQLite 数据库文件直接进入数据库页面
+		if isSQLiteFile(path) {
+			_this.openSQLiteFile(path)
+			return nil
+		}
+
 		if _this.app.UI.GetFocus() == _this.preview {
 			return nil // 已经在 preview 上了
 		}
@@ -261,6 +280,50 @@ func (_this *FileBrowser) TabFocusChange(event *tcell.EventKey) *tcell.EventKey
 	return nil
 }
 
+// isSQLiteFile 通过文件头判断是否为 SQLite 数据库文件
+func isSQLiteFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	header := make([]byte, len(sqliteFileHeader))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return string(header) == sqliteFileHeader
+}
+
+// pickFile 返回上一页并回调选中文件的绝对路径
+func (_this *FileBrowser) pickFile(path string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	_this.app.Content.Pop()
+	_this.pickFn(absPath)
+}
+
+// openSQLiteFile 以 SQLite 连接打开数据库文件
+func (_this *FileBrowser) openSQLiteFile(path string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	loading := dialog.ShowLoadingDialog(appViewInstance.Content.Pages, "", appUiInstance.ForceDraw)
+	mainPage := NewDatabaseMainPage(_this.app, &config.DBConnection{
+		Name:     filepath.Base(absPath),
+		Provider: config.DatabaseProviderSQLite,
+		FilePath: absPath,
+	})
+	if err := _this.app.inject(mainPage, false); err != nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to inject database main page: %w", err))
+	}
+	loading.Hide()
+}
+
 // fileCtrl
 func (_this *FileBrowser) fileCtrl(event *tcell.EventKey) *tcell.EventKey {
 	node := _this.tree.GetCurrentNode()
@@ -489,3 +552,10 @@ func NewFileBrowser(app *App) *FileBrowser {
 	f.SetIdentifier(ui.FILE_BROWSER_ID)
 	return f
 }
+
+// NewFilePicker 以选择文件的方式打开文件浏览器，在文件上按 Enter 后返回上一页
+func NewFilePicker(app *App, fn func(path string)) *FileBrowser {
+	f := NewFileBrowser(app)
+	f.pickFn = fn
+	return f
+}
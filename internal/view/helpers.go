@@ -3,6 +3,9 @@ package view
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/liangzhaoliang95/lxz/internal"
 )
@@ -15,3 +18,15 @@ func extractApp(ctx context.Context) (*App, error) {
 
 	return app, nil
 }
+
+// expandHomePath 展开路径开头的 ~
+func expandHomePath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path[1:], "/")), nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/config"
@@ -109,6 +110,15 @@ func (_this *RedisBrowser) _initRedisConfigTableHeader() {
 			SetExpansion(1).
 			SetSelectable(false),
 	)
+	_this.connListTableUI.SetCell(
+		0,
+		4,
+		tview.NewTableCell("Mode").
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1).
+			SetSelectable(false),
+	)
 }
 
 func (_this *RedisBrowser) _refreshTableData() {
@@ -124,6 +134,10 @@ func (_this *RedisBrowser) _refreshTableData() {
 		_this._initRedisConfigTableHeader()
 		// 设置数据
 		for i, connection := range _this.config.RedisConnConfig {
+			host := connection.Host
+			if connection.GetMode() != config.RedisModeStandalone {
+				host = strings.Join(connection.SeedAddrs(), ",")
+			}
 			_this.connListTableUI.SetCell(
 				i+1,
 				0,
@@ -135,7 +149,7 @@ func (_this *RedisBrowser) _refreshTableData() {
 			_this.connListTableUI.SetCell(
 				i+1,
 				1,
-				tview.NewTableCell(connection.Host).
+				tview.NewTableCell(host).
 					SetTextColor(tcell.ColorWhite).
 					SetAlign(tview.AlignLeft).
 					SetExpansion(1),
@@ -156,6 +170,14 @@ func (_this *RedisBrowser) _refreshTableData() {
 					SetAlign(tview.AlignLeft).
 					SetExpansion(1),
 			)
+			_this.connListTableUI.SetCell(
+				i+1,
+				4,
+				tview.NewTableCell(connection.GetMode()).
+					SetTextColor(tcell.ColorWhite).
+					SetAlign(tview.AlignLeft).
+					SetExpansion(1),
+			)
 		}
 	})
 }
@@ -218,12 +240,7 @@ func (_this *RedisBrowser) createRedisConfigModel(evt *tcell.EventKey) *tcell.Ev
 						Warn("Connection already exists. Please choose a different name.")
 					return false
 				}
-				if opts.Host == "" {
-					_this.app.UI.Flash().Warn("Host cannot be empty.")
-					return false
-				}
-				if opts.Port <= 0 {
-					_this.app.UI.Flash().Warn("Port must be a positive integer.")
+				if !_this._checkConnection(opts) {
 					return false
 				}
 
@@ -274,12 +291,7 @@ func (_this *RedisBrowser) createRedisConfigModel(evt *tcell.EventKey) *tcell.Ev
 					}
 				}
 
-				if newConfig.Host == "" {
-					_this.app.UI.Flash().Warn("Host cannot be empty.")
-					return false
-				}
-				if newConfig.Port <= 0 {
-					_this.app.UI.Flash().Warn("Port must be a positive integer.")
+				if !_this._checkConnection(newConfig) {
 					return false
 				}
 
@@ -288,6 +300,8 @@ func (_this *RedisBrowser) createRedisConfigModel(evt *tcell.EventKey) *tcell.Ev
 					_this.app.UI.Flash().Warn("Failed to save configuration: " + err.Error())
 					return false
 				}
+				// 配置变更后丢弃旧的客户端
+				redis_drivers.ResetConnect(_this.selectKey)
 				_this._refreshTableData()
 				return true
 			},
@@ -308,6 +322,31 @@ func (_this *RedisBrowser) createRedisConfigModel(evt *tcell.EventKey) *tcell.Ev
 	return nil
 }
 
+// _checkConnection 校验连接配置，集群和哨兵模式需要节点地址
+func (_this *RedisBrowser) _checkConnection(conn *config.RedisConnConfig) bool {
+	switch conn.GetMode() {
+	case config.RedisModeCluster, config.RedisModeSentinel:
+		if len(conn.Addrs) == 0 && conn.Host == "" {
+			_this.app.UI.Flash().Warn("Addrs cannot be empty.")
+			return false
+		}
+		if conn.GetMode() == config.RedisModeSentinel && conn.MasterName == "" {
+			_this.app.UI.Flash().Warn("Master name cannot be empty.")
+			return false
+		}
+		return true
+	}
+	if conn.Host == "" {
+		_this.app.UI.Flash().Warn("Host cannot be empty.")
+		return false
+	}
+	if conn.Port <= 0 {
+		_this.app.UI.Flash().Warn("Port must be a positive integer.")
+		return false
+	}
+	return true
+}
+
 // deleteRedisConnectionModel 删除连接
 func (_this *RedisBrowser) deleteRedisConnectionModel(evt *tcell.EventKey) *tcell.EventKey {
 	_this._getCurrentSelectKey()
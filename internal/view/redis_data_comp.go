@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/liangzhaoliang95/lxz/internal/config"
@@ -41,9 +42,25 @@ type RedisDataComponent struct {
 	keyName     *tview.TextView // 键名称
 	KeyType     *tview.TextView // 键类型
 	keyTTL      *tview.TextView // 键的TTL
-	KeyValue    *tview.TextView // 键的内容
+	keyContent  *tview.Pages    // 键的内容，按类型切换文本或表格
+	KeyValue    *tview.TextView // string 类型键的内容
+	// 集合类型键的内容，按类型展示 field/value、index/value、member/score、id/fields
+	keyValueTable *tview.Table
+
+	// 当前展示的键
+	currentKey   string
+	currentType  string
+	currentNext  string // 下一页游标，为空表示已加载完
+	currentTotal int64  // 集合元素总数
 }
 
+const (
+	redisContentTextPage  = "text"
+	redisContentTablePage = "table"
+	// 批量删除预览时展示的示例键数量
+	redisDeleteSampleSize = 10
+)
+
 // refreshData
 func (_this *RedisDataComponent) refreshData() error {
 	// 刷新数据
@@ -67,8 +84,9 @@ func (_this *RedisDataComponent) newKey() {
 		&config.Dialog{},
 		_this.app.Content.Pages,
 		&dialog.CreateUpdateRedisDataOpts{
-			Title:   "New Key",
-			Message: "",
+			Title:        "New Key",
+			Message:      "",
+			TypeEditable: true,
 			Data: &redis_drivers.RedisData{
 				KetType: "string",
 				KeyTTL:  -1,
@@ -112,6 +130,10 @@ func (_this *RedisDataComponent) editKey() {
 		_this.app.UI.Flash().Err(fmt.Errorf("failed to get key data: %w", err))
 		return
 	}
+	if keyData.KetType != redis_drivers.RedisTypeString {
+		_this.app.UI.Flash().Warn("Select the key content to edit its elements.")
+		return
+	}
 
 	dialog.ShowCreateUpdateRedisData(
 		&config.Dialog{},
@@ -144,22 +166,118 @@ func (_this *RedisDataComponent) editKey() {
 	)
 }
 
+// deleteKey 删除选中的键，分组节点删除该分组下的所有键
 func (_this *RedisDataComponent) deleteKey() {
 	// 获取当前选中的键
 	selectedNode := _this.keyGroupTree.GetCurrentNode()
 	slog.Info("deleteKey", "selectedNode", selectedNode)
-	key := selectedNode.GetReference().(string)
-	dialog.ShowDelete(&config.Dialog{}, _this.app.Content.Pages, key, func(force bool) {
-		_this.rdbClient.DeleteKey(key)
-		parent := selectedNode.GetParentNode()
-		parent.RemoveChild(selectedNode)
+	key, ok := selectedNode.GetReference().(string)
+	if !ok {
+		_this.app.UI.Flash().Warn("Please select a key first.")
+		return
+	}
 
-		_this.focusKeyGroupTree()
+	if len(selectedNode.GetChildren()) == 0 {
+		msg := fmt.Sprintf("Delete key %s?", key)
+		dialog.ShowDelete(&config.Dialog{}, _this.app.Content.Pages, msg, func(force bool) {
+			if err := _this.rdbClient.DeleteKey(key); err != nil {
+				_this.app.UI.Flash().Err(err)
+				return
+			}
+			parent := selectedNode.GetParentNode()
+			parent.RemoveChild(selectedNode)
+		}, func() {
+			_this.focusKeyGroupTree()
+		})
+		return
+	}
+
+	// 分组节点，先统计分组下的键数量
+	count, _, err := _this.rdbClient.PreviewPattern(redis_drivers.GroupPattern(key), 0)
+	if err != nil {
+		_this.app.UI.Flash().Err(err)
+		return
+	}
+	msg := fmt.Sprintf("Delete key group %s and the %d keys under it?", key, count)
+	dialog.ShowDelete(&config.Dialog{}, _this.app.Content.Pages, msg, func(force bool) {
+		_this.runBatchDelete(count, func(progress redis_drivers.DeleteProgressFn) (int64, error) {
+			return _this.rdbClient.DeleteKeyGroup(key, progress)
+		})
 	}, func() {
 		_this.focusKeyGroupTree()
 	})
 }
 
+// deleteByPattern 按匹配模式批量删除键
+func (_this *RedisDataComponent) deleteByPattern() {
+	// 默认使用当前分组或搜索条件
+	pattern := "*"
+	if _this.filterInput.GetText() != "" {
+		pattern = "*" + _this.filterInput.GetText() + "*"
+	}
+	if node := _this.keyGroupTree.GetCurrentNode(); node != nil && len(node.GetChildren()) > 0 {
+		if key, ok := node.GetReference().(string); ok {
+			pattern = redis_drivers.GroupPattern(key)
+		}
+	}
+
+	var total int64
+	dialog.ShowRedisPatternDelete(&config.Dialog{}, _this.app.Content.Pages, &dialog.RedisPatternDeleteOpts{
+		Pattern: pattern,
+		Preview: func(pattern string) (string, bool) {
+			count, sample, err := _this.rdbClient.PreviewPattern(pattern, redisDeleteSampleSize)
+			if err != nil {
+				return err.Error(), false
+			}
+			total = count
+			msg := fmt.Sprintf("Matched %d keys", count)
+			if len(sample) > 0 {
+				msg += ":\n" + strings.Join(sample, "\n")
+			}
+			if count > int64(len(sample)) {
+				msg += "\n..."
+			}
+			return msg, count > 0
+		},
+		Ack: func(pattern string) {
+			_this.runBatchDelete(total, func(progress redis_drivers.DeleteProgressFn) (int64, error) {
+				return _this.rdbClient.DeleteByPattern(pattern, progress)
+			})
+		},
+		Cancel: func() {
+			_this.focusKeyGroupTree()
+		},
+	})
+}
+
+// runBatchDelete 后台执行批量删除，并在加载框中展示删除进度
+func (_this *RedisDataComponent) runBatchDelete(
+	total int64,
+	deleteFn func(progress redis_drivers.DeleteProgressFn) (int64, error),
+) {
+	loading := dialog.ShowLoadingDialog(
+		_this.app.Content.Pages,
+		fmt.Sprintf("⏳ Deleting %d keys...", total),
+		_this.app.UI.ForceDraw,
+	)
+	go func() {
+		deleted, err := deleteFn(func(deleted int64) {
+			_this.app.UI.QueueUpdateDraw(func() {
+				loading.SetMessage(fmt.Sprintf("⏳ Deleted %d/%d keys...", deleted, total))
+			})
+		})
+		_this.app.UI.QueueUpdateDraw(func() {
+			loading.Hide()
+			if err != nil {
+				_this.app.UI.Flash().Err(fmt.Errorf("deleted %d keys before failing: %w", deleted, err))
+			} else {
+				_this.app.UI.Flash().Info(fmt.Sprintf("Deleted %d keys", deleted))
+			}
+			_ = _this.refreshData()
+		})
+	}()
+}
+
 func (_this *RedisDataComponent) focusSearch() {
 	// 设置当前焦点为搜索框
 	_this.app.UI.SetFocus(_this.filterFlex)
@@ -171,9 +289,88 @@ func (_this *RedisDataComponent) focusKeyGroupTree() {
 }
 
 func (_this *RedisDataComponent) focusKeyInfoFlex() {
+	if name, _ := _this.keyContent.GetFrontPage(); name == redisContentTablePage {
+		_this.app.UI.SetFocus(_this.keyValueTable)
+		return
+	}
 	_this.app.UI.SetFocus(_this.KeyValue)
 }
 
+// showKeyData 读取并展示键的详细信息，集合类型以表格分页展示
+func (_this *RedisDataComponent) showKeyData(key string) error {
+	keyData, err := _this.rdbClient.GetKeyData(key)
+	if err != nil {
+		return err
+	}
+	_this.keyName.SetText(key)
+	_this.KeyType.SetText(keyData.KetType)
+	_this.keyTTL.SetText(fmt.Sprintf("%d", keyData.KeyTTL))
+	_this.currentKey = key
+	_this.currentType = keyData.KetType
+
+	if keyData.KeyValues == nil {
+		_this.currentNext = ""
+		_this.currentTotal = 0
+		_this.KeyValue.SetText(helper.Prettify(keyData.KeyValue))
+		_this.KeyValue.ScrollToBeginning()
+		_this.keyContent.SwitchToPage(redisContentTextPage)
+		return nil
+	}
+
+	_this.keyValueTable.Clear()
+	for i, column := range keyData.KeyValues.Columns {
+		_this.keyValueTable.SetCell(0, i, tview.NewTableCell(column).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetExpansion(1))
+	}
+	_this.appendKeyValues(keyData.KeyValues)
+	_this.keyValueTable.ScrollToBeginning()
+	_this.keyValueTable.Select(1, 0)
+	_this.keyContent.SwitchToPage(redisContentTablePage)
+	return nil
+}
+
+// appendKeyValues 将一页集合数据追加到表格末尾
+func (_this *RedisDataComponent) appendKeyValues(value *redis_drivers.RedisValue) {
+	start := _this.keyValueTable.GetRowCount()
+	for i, row := range value.Rows {
+		for j, cell := range row {
+			text := strings.ReplaceAll(tview.Escape(cell), "\n", "\\n")
+			_this.keyValueTable.SetCell(start+i, j, tview.NewTableCell(text).
+				SetTextColor(tcell.ColorBlue).
+				SetReference(cell).
+				SetExpansion(1))
+		}
+	}
+	_this.currentNext = value.Next
+	_this.currentTotal = value.Total
+	_this.keyValueTable.SetTitle(fmt.Sprintf(
+		"CONTENT (%d/%d)",
+		_this.keyValueTable.GetRowCount()-1,
+		_this.currentTotal,
+	))
+}
+
+// loadMoreKeyValues 读取下一页集合数据
+func (_this *RedisDataComponent) loadMoreKeyValues() {
+	if _this.currentNext == "" {
+		return
+	}
+	value, err := _this.rdbClient.GetKeyValues(
+		_this.currentKey,
+		_this.currentType,
+		_this.currentNext,
+		redis_drivers.DefaultValuePageSize,
+	)
+	if err != nil {
+		_this.currentNext = ""
+		_this.app.UI.Flash().Err(fmt.Errorf("failed to load more values: %w", err))
+		return
+	}
+	_this.appendKeyValues(value)
+}
+
 func (_this *RedisDataComponent) refreshRedisData() error {
 	// 初始化数据库连接
 	iRedisConn, err := redis_drivers.GetConnectOrInit(_this.redisConnConfig, _this.dbNum)
@@ -292,37 +489,11 @@ func (_this *RedisDataComponent) Init(ctx context.Context) error {
 		} else {
 			// 如果没有子节点，获取当前节点的完整路径
 			fullPath := node.GetReference().(string)
-			// key
-			_this.keyName.SetText(fullPath)
-
-			// 获取key值
-			keyValue, err := _this.rdbClient.GetKeyValue(fullPath)
-			if err != nil {
-				slog.Error("Failed to get key value", "key", fullPath, "error", err)
-				_this.app.UI.Flash().Err(fmt.Errorf("failed to get key value: %w", err))
-				return
-			}
-			slog.Info(helper.Prettify(keyValue))
-			_this.KeyValue.SetText(helper.Prettify(keyValue))
-			// 获取key类型
-			keyType, err := _this.rdbClient.GetKeyType(fullPath)
-			if err != nil {
-				slog.Error("Failed to get key type", "key", fullPath, "error", err)
-				_this.app.UI.Flash().Err(fmt.Errorf("failed to get key type: %w", err))
+			if err := _this.showKeyData(fullPath); err != nil {
+				slog.Error("Failed to get key data", "key", fullPath, "error", err)
+				_this.app.UI.Flash().Err(fmt.Errorf("failed to get key data: %w", err))
 				return
 			}
-			_this.KeyType.SetText(keyType)
-
-			// 获取TTL
-			ttl, err := _this.rdbClient.GetKeyTTL(fullPath)
-			if err != nil {
-				slog.Error("Failed to get key TTL", "key", fullPath, "error", err)
-				_this.app.UI.Flash().Err(fmt.Errorf("failed to get key TTL: %w", err))
-				return
-			}
-			_this.keyTTL.SetText(fmt.Sprintf("%d", ttl))
-
-			slog.Info("Selected key info", "TTL", ttl, "key", fullPath, "type", keyType, "value", keyValue)
 			_this.focusKeyInfoFlex()
 		}
 	})
@@ -376,7 +547,36 @@ func (_this *RedisDataComponent) Init(ctx context.Context) error {
 		// 设置当前焦点为键分组树
 		_this.KeyValue.SetBorderColor(base.InactiveBorderColor)
 	})
-	_this.keyInfoFlex.AddItem(_this.KeyValue, 0, 1, false)
+
+	// 初始化keyValueTable
+	_this.keyValueTable = tview.NewTable()
+	_this.keyValueTable.SetBorder(true)
+	_this.keyValueTable.SetTitle("CONTENT")
+	_this.keyValueTable.SetTitleAlign(tview.AlignCenter)
+	_this.keyValueTable.SetSeparator(tview.Borders.Vertical)
+	_this.keyValueTable.SetSelectedStyle(
+		tcell.StyleDefault.Background(tcell.ColorRed).
+			Foreground(tview.Styles.ContrastSecondaryTextColor),
+	)
+	_this.keyValueTable.SetSelectable(true, false)
+	_this.keyValueTable.SetFixed(1, 0)
+	_this.keyValueTable.SetSelectionChangedFunc(func(row, column int) {
+		// 滚动到最后一行时加载下一页
+		if row == _this.keyValueTable.GetRowCount()-1 {
+			_this.loadMoreKeyValues()
+		}
+	})
+	_this.keyValueTable.SetFocusFunc(func() {
+		_this.keyValueTable.SetBorderColor(base.ActiveBorderColor)
+	})
+	_this.keyValueTable.SetBlurFunc(func() {
+		_this.keyValueTable.SetBorderColor(base.InactiveBorderColor)
+	})
+
+	_this.keyContent = tview.NewPages()
+	_this.keyContent.AddPage(redisContentTextPage, _this.KeyValue, true, true)
+	_this.keyContent.AddPage(redisContentTablePage, _this.keyValueTable, true, false)
+	_this.keyInfoFlex.AddItem(_this.keyContent, 0, 1, false)
 
 	return nil
 }
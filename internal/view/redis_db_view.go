@@ -134,8 +134,13 @@ func (_this *RedisDbListView) Init(ctx context.Context) error {
 func (_this *RedisDbListView) Start() {
 	slog.Info("DatabaseDbTree Start", "redis", _this.connConfig.Name)
 	for i := 0; i < _this.dbNum; i++ {
+		// 集群模式没有 db 的概念，只展示一个集群节点
+		dbLabel := fmt.Sprintf("%d", i)
+		if _this.connConfig.IsCluster() {
+			dbLabel = config.RedisModeCluster
+		}
 		_this.dbListUI.SetCell(i+1, 0,
-			tview.NewTableCell(fmt.Sprintf("%d", i)).
+			tview.NewTableCell(dbLabel).
 				SetTextColor(tcell.ColorBlue).
 				SetAlign(tview.AlignCenter).
 				SetExpansion(1).
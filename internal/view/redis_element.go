@@ -0,0 +1,231 @@
+/**
+ * @author  zhaoliang.liang
+ * @date  2025/8/19 16:30
+ */
+
+package view
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/liangzhaoliang95/lxz/internal/config"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/redis_drivers"
+	"github.com/liangzhaoliang95/lxz/internal/ui/dialog"
+)
+
+// selectedElement 获取内容表格中当前选中的元素
+func (_this *RedisDataComponent) selectedElement() *dialog.RedisElement {
+	row, _ := _this.keyValueTable.GetSelection()
+	if row <= 0 || row >= _this.keyValueTable.GetRowCount() {
+		return nil
+	}
+	cellValue := func(column int) string {
+		cell := _this.keyValueTable.GetCell(row, column)
+		if ref, ok := cell.GetReference().(string); ok {
+			return ref
+		}
+		return ""
+	}
+	switch _this.currentType {
+	case redis_drivers.RedisTypeSet:
+		return &dialog.RedisElement{Value: cellValue(0)}
+	case redis_drivers.RedisTypeZSet:
+		return &dialog.RedisElement{Value: cellValue(0), Field: cellValue(1)}
+	default:
+		// hash: field/value，list: index/value，stream: id/fields
+		return &dialog.RedisElement{Field: cellValue(0), Value: cellValue(1)}
+	}
+}
+
+// reloadKeyData 元素变更后重新加载当前键，键被删空时刷新键列表
+func (_this *RedisDataComponent) reloadKeyData() {
+	if err := _this.showKeyData(_this.currentKey); err != nil {
+		slog.Warn("Failed to reload key data", "key", _this.currentKey, "error", err)
+		_ = _this.refreshData()
+		return
+	}
+	_this.focusKeyInfoFlex()
+}
+
+// newElement 向当前集合类型键添加元素
+func (_this *RedisDataComponent) newElement() {
+	if _this.currentType == redis_drivers.RedisTypeString {
+		_this.app.UI.Flash().Warn("String keys have no elements.")
+		return
+	}
+	element := &dialog.RedisElement{}
+	if _this.currentType == redis_drivers.RedisTypeStream {
+		element.Field = "*"
+	}
+	dialog.ShowRedisElement(&config.Dialog{}, _this.app.Content.Pages, &dialog.RedisElementOpts{
+		Title:   "New Element",
+		KeyType: _this.currentType,
+		Element: element,
+		Ack: func(e *dialog.RedisElement) bool {
+			if err := _this.addElement(e); err != nil {
+				slog.Error("Failed to add element", "key", _this.currentKey, "error", err)
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.reloadKeyData()
+			return true
+		},
+		Cancel: func() {
+			_this.focusKeyInfoFlex()
+		},
+	})
+}
+
+// editElement 编辑内容表格中选中的元素
+func (_this *RedisDataComponent) editElement() {
+	old := _this.selectedElement()
+	if old == nil {
+		_this.app.UI.Flash().Warn("Please select an element first.")
+		return
+	}
+	if _this.currentType == redis_drivers.RedisTypeStream {
+		_this.app.UI.Flash().Warn("Stream messages cannot be edited, add a new one instead.")
+		return
+	}
+	element := *old
+	dialog.ShowRedisElement(&config.Dialog{}, _this.app.Content.Pages, &dialog.RedisElementOpts{
+		Title:   "Edit Element",
+		KeyType: _this.currentType,
+		Edit:    true,
+		Element: &element,
+		Ack: func(e *dialog.RedisElement) bool {
+			if err := _this.updateElement(old, e); err != nil {
+				slog.Error("Failed to edit element", "key", _this.currentKey, "error", err)
+				_this.app.UI.Flash().Err(err)
+				return false
+			}
+			_this.reloadKeyData()
+			return true
+		},
+		Cancel: func() {
+			_this.focusKeyInfoFlex()
+		},
+	})
+}
+
+// deleteElement 删除内容表格中选中的元素
+func (_this *RedisDataComponent) deleteElement() {
+	element := _this.selectedElement()
+	if element == nil {
+		_this.app.UI.Flash().Warn("Please select an element first.")
+		return
+	}
+	name := element.Value
+	if _this.currentType == redis_drivers.RedisTypeHash ||
+		_this.currentType == redis_drivers.RedisTypeList ||
+		_this.currentType == redis_drivers.RedisTypeStream {
+		name = element.Field
+	}
+	msg := fmt.Sprintf("Delete %s element %s from %s?", _this.currentType, name, _this.currentKey)
+	dialog.ShowDelete(&config.Dialog{}, _this.app.Content.Pages, msg, func(force bool) {
+		if err := _this.removeElement(element); err != nil {
+			slog.Error("Failed to delete element", "key", _this.currentKey, "error", err)
+			_this.app.UI.Flash().Err(err)
+			return
+		}
+		_this.reloadKeyData()
+	}, func() {
+		_this.focusKeyInfoFlex()
+	})
+}
+
+func (_this *RedisDataComponent) addElement(e *dialog.RedisElement) error {
+	key := _this.currentKey
+	switch _this.currentType {
+	case redis_drivers.RedisTypeHash:
+		if e.Field == "" {
+			return fmt.Errorf("field cannot be empty")
+		}
+		return _this.rdbClient.HashSetField(key, e.Field, e.Value)
+	case redis_drivers.RedisTypeList:
+		return _this.rdbClient.ListPush(key, e.Value, e.Head)
+	case redis_drivers.RedisTypeSet:
+		return _this.rdbClient.SetAddMember(key, e.Value)
+	case redis_drivers.RedisTypeZSet:
+		score, err := strconv.ParseFloat(e.Field, 64)
+		if err != nil {
+			return fmt.Errorf("invalid score: %s", e.Field)
+		}
+		return _this.rdbClient.ZSetAddMember(key, e.Value, score)
+	case redis_drivers.RedisTypeStream:
+		fields, err := redis_drivers.ParsePairs(e.Value)
+		if err != nil {
+			return err
+		}
+		_, err = _this.rdbClient.StreamAdd(key, e.Field, fields)
+		return err
+	default:
+		return fmt.Errorf("unsupported key type: %s", _this.currentType)
+	}
+}
+
+func (_this *RedisDataComponent) updateElement(old, e *dialog.RedisElement) error {
+	key := _this.currentKey
+	switch _this.currentType {
+	case redis_drivers.RedisTypeHash:
+		if e.Field == "" {
+			return fmt.Errorf("field cannot be empty")
+		}
+		if e.Field != old.Field {
+			return _this.rdbClient.HashRenameField(key, old.Field, e.Field, e.Value)
+		}
+		return _this.rdbClient.HashSetField(key, e.Field, e.Value)
+	case redis_drivers.RedisTypeList:
+		index, err := strconv.ParseInt(old.Field, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid list index: %s", old.Field)
+		}
+		return _this.rdbClient.ListSetIndex(key, index, e.Value)
+	case redis_drivers.RedisTypeSet:
+		if e.Value == old.Value {
+			return nil
+		}
+		return _this.rdbClient.SetReplaceMember(key, old.Value, e.Value)
+	case redis_drivers.RedisTypeZSet:
+		score, err := strconv.ParseFloat(e.Field, 64)
+		if err != nil {
+			return fmt.Errorf("invalid score: %s", e.Field)
+		}
+		return _this.rdbClient.ZSetAddMember(key, old.Value, score)
+	default:
+		return fmt.Errorf("unsupported key type: %s", _this.currentType)
+	}
+}
+
+func (_this *RedisDataComponent) removeElement(e *dialog.RedisElement) error {
+	key := _this.currentKey
+	switch _this.currentType {
+	case redis_drivers.RedisTypeHash:
+		return _this.rdbClient.HashDeleteField(key, e.Field)
+	case redis_drivers.RedisTypeList:
+		index, err := strconv.ParseInt(e.Field, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid list index: %s", e.Field)
+		}
+		// 首尾元素直接弹出，中间元素按下标删除
+		switch index {
+		case 0:
+			_, err = _this.rdbClient.ListPop(key, true)
+		case _this.currentTotal - 1:
+			_, err = _this.rdbClient.ListPop(key, false)
+		default:
+			err = _this.rdbClient.ListRemoveIndex(key, index)
+		}
+		return err
+	case redis_drivers.RedisTypeSet:
+		return _this.rdbClient.SetRemoveMember(key, e.Value)
+	case redis_drivers.RedisTypeZSet:
+		return _this.rdbClient.ZSetRemoveMember(key, e.Value)
+	case redis_drivers.RedisTypeStream:
+		return _this.rdbClient.StreamDelete(key, e.Field)
+	default:
+		return fmt.Errorf("unsupported key type: %s", _this.currentType)
+	}
+}
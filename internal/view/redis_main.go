@@ -35,10 +35,11 @@ func (_this *RedisMainPage) bindKeys() {
 		ui.KeySlash:     ui.NewKeyAction("Search", _this.ToggleSearch, true),
 		tcell.KeyEscape: ui.NewKeyAction("Last Page", _this.EmptyKeyEvent, true),
 		tcell.KeyTAB:    ui.NewKeyAction("Focus Change", _this.TabFocusChange, true),
-		tcell.KeyCtrlD:  ui.NewKeyAction("Delete Key", _this.DeleteKey, true),
-		tcell.KeyCtrlN:  ui.NewKeyAction("Delete Key", _this.NewKey, true),
+		tcell.KeyCtrlD:  ui.NewKeyAction("Delete Key/Element", _this.DeleteKey, true),
+		tcell.KeyCtrlN:  ui.NewKeyAction("New Key/Element", _this.NewKey, true),
 		tcell.KeyCtrlR:  ui.NewKeyAction("Refresh", _this.Refresh, true),
-		ui.KeyE:         ui.NewKeyAction("Edit Key", _this.EditKey, true),
+		ui.KeyX:         ui.NewKeyAction("Delete By Pattern", _this.DeleteByPattern, true),
+		ui.KeyE:         ui.NewKeyAction("Edit Key/Element", _this.EditKey, true),
 	})
 }
 
@@ -63,6 +64,11 @@ func (_this *RedisMainPage) Refresh(event *tcell.EventKey) *tcell.EventKey {
 // NewKey 创建一个新的键
 func (_this *RedisMainPage) NewKey(event *tcell.EventKey) *tcell.EventKey {
 	currentCompPage := _this.dataViewUI.redisDataComponents[_this.dataViewUI.currentPageKey]
+	if _this.app.UI.GetFocus() == currentCompPage.keyValueTable {
+		// 当前焦点在内容表格上，操作集合元素
+		currentCompPage.newElement()
+		return nil
+	}
 	if _this.app.UI.GetFocus() != currentCompPage.keyGroupTree {
 		_this.app.UI.Flash().Err(fmt.Errorf("please select a key first"))
 		return nil
@@ -80,6 +86,11 @@ func (_this *RedisMainPage) EditKey(event *tcell.EventKey) *tcell.EventKey {
 		return event
 	}
 	currentCompPage := _this.dataViewUI.redisDataComponents[_this.dataViewUI.currentPageKey]
+	if _this.app.UI.GetFocus() == currentCompPage.keyValueTable {
+		// 当前焦点在内容表格上，操作集合元素
+		currentCompPage.editElement()
+		return nil
+	}
 	if _this.app.UI.GetFocus() != currentCompPage.keyGroupTree {
 		_this.app.UI.Flash().Err(fmt.Errorf("please select a key first"))
 		return nil
@@ -93,6 +104,11 @@ func (_this *RedisMainPage) EditKey(event *tcell.EventKey) *tcell.EventKey {
 // DeleteKey 删除当前选中的键
 func (_this *RedisMainPage) DeleteKey(event *tcell.EventKey) *tcell.EventKey {
 	currentCompPage := _this.dataViewUI.redisDataComponents[_this.dataViewUI.currentPageKey]
+	if _this.app.UI.GetFocus() == currentCompPage.keyValueTable {
+		// 当前焦点在内容表格上，操作集合元素
+		currentCompPage.deleteElement()
+		return nil
+	}
 	if _this.app.UI.GetFocus() != currentCompPage.keyGroupTree {
 		_this.app.UI.Flash().Err(fmt.Errorf("please select a key first"))
 		return nil
@@ -103,13 +119,28 @@ func (_this *RedisMainPage) DeleteKey(event *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+// DeleteByPattern 按匹配模式批量删除键
+func (_this *RedisMainPage) DeleteByPattern(event *tcell.EventKey) *tcell.EventKey {
+	// 如果当前焦点在输入框上，不拦截按键事件，让用户正常输入
+	if ui.IsInputPrimitive(_this.app.UI.GetFocus()) {
+		return event
+	}
+	currentCompPage := _this.dataViewUI.redisDataComponents[_this.dataViewUI.currentPageKey]
+	if currentCompPage == nil {
+		_this.app.UI.Flash().Err(fmt.Errorf("select one db first"))
+		return nil
+	}
+	currentCompPage.deleteByPattern()
+	return nil
+}
+
 func (_this *RedisMainPage) TabFocusChange(event *tcell.EventKey) *tcell.EventKey {
 	currentCompPage := _this.dataViewUI.redisDataComponents[_this.dataViewUI.currentPageKey]
 	currentFocus := _this.app.UI.GetFocus()
 	switch currentFocus {
 	case _this.dbListViewUI.dbListUI, _this.dbListViewUI:
 		_this.dataViewUI.selfFocus()
-	case currentCompPage.KeyValue:
+	case currentCompPage.KeyValue, currentCompPage.keyValueTable:
 		_this.dataViewUI.selfFocus()
 	default:
 		_this.dbListViewUI.selfFocus()
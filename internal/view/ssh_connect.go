@@ -6,17 +6,14 @@
 package view
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
-	"os/user"
-	"path/filepath"
-	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/liangzhaoliang95/lxz/internal/drivers/ssh_drivers"
 	"github.com/liangzhaoliang95/lxz/internal/ui"
 	"github.com/liangzhaoliang95/lxz/internal/view/base"
 	"github.com/liangzhaoliang95/tview"
@@ -25,7 +22,7 @@ import (
 type SshConnect struct {
 	*BaseFlex
 	app       *App
-	envMap    map[string][]HostItem
+	envMap    map[string][]ssh_drivers.HostItem
 	envOrder  []string
 	envList   *tview.List
 	hostTable *tview.Table
@@ -45,10 +42,10 @@ func (_this *SshConnect) bindKeys() {
 func (_this *SshConnect) Init(ctx context.Context) error {
 	// 用于初始化组件的边框、标题、快捷键等信息
 	var err error
-	_this.envMap, _this.envOrder, err = loadAllHostsGrouped()
+	_this.envMap, _this.envOrder, err = ssh_drivers.LoadAllHostsGrouped()
 	if err != nil {
 		slog.Error(
-			fmt.Sprintf("%s loadAllHostsGrouped failed err => %s", _this.Name(), err.Error()),
+			fmt.Sprintf("%s LoadAllHostsGrouped failed err => %s", _this.Name(), err.Error()),
 		)
 		return err
 	}
@@ -190,164 +187,6 @@ func (_this *SshConnect) TabFocusChange(event *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
-// helpers
-
-type HostItem struct {
-	Host         string
-	HostName     string
-	User         string
-	Port         string
-	Jump         string
-	Source       string
-	FilePath     string
-	IdentityFile string
-	ProxyCommand string
-}
-
-// 解析配置文件，只返回含 HostName 字段的 Host
-func parseConfigFileWithHostname(path string) ([]HostItem, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	scanner := bufio.NewScanner(file)
-
-	var results []HostItem
-	var currentHosts []string
-	var hostName, user, port, identityFile, proxyCommand string
-	inHostBlock := false
-
-	base := filepath.Base(path)
-
-	saveCurrentHostBlock := func() {
-		if len(currentHosts) > 0 && hostName != "" {
-			for _, h := range currentHosts {
-				results = append(results, HostItem{
-					Host:         h,
-					HostName:     hostName,
-					User:         user,
-					Port:         port,
-					IdentityFile: identityFile,
-					ProxyCommand: proxyCommand,
-					Source:       base,
-					FilePath:     path,
-				})
-			}
-		}
-	}
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		if strings.HasPrefix(line, "Host ") {
-			// 保存上一个 Host 块
-			saveCurrentHostBlock()
-
-			// 开启新块
-			currentHosts = strings.Fields(line)[1:]
-			hostName, user, port, identityFile, proxyCommand = "", "", "", "", ""
-			inHostBlock = true
-		} else if inHostBlock {
-			fields := strings.Fields(line)
-			if len(fields) < 2 {
-				continue
-			}
-			key := strings.ToLower(fields[0])
-			value := strings.Join(fields[1:], " ")
-			switch key {
-			case "hostname":
-				hostName = value
-			case "user":
-				user = value
-			case "port":
-				port = value
-			case "identityfile":
-				identityFile = value
-			case "proxycommand":
-				proxyCommand = value
-			}
-		}
-	}
-
-	// 保存最后一个块
-	saveCurrentHostBlock()
-	return results, nil
-}
-
-func loadAllHostsGrouped() (map[string][]HostItem, []string, error) {
-	usr, _ := user.Current()
-	mainConfig := filepath.Join(usr.HomeDir, ".ssh", "config")
-
-	queue := []string{mainConfig}
-	visited := make(map[string]bool)
-	sourceOrder := []string{}
-	hostMap := make(map[string][]HostItem)
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if visited[current] {
-			continue
-		}
-		visited[current] = true
-
-		items, err := parseConfigFileWithHostname(current)
-		if err == nil && len(items) > 0 {
-			source := filepath.Base(current)
-			hostMap[source] = append(hostMap[source], items...)
-			sourceOrder = append(sourceOrder, source)
-		}
-
-		f, err := os.Open(current)
-		if err != nil {
-			continue
-		}
-		defer func() {
-			_ = f.Close()
-		}()
-
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if strings.HasPrefix(line, "Include ") {
-				included := expandIncludes(line)
-				queue = append(queue, included...)
-			}
-		}
-	}
-
-	return hostMap, sourceOrder, nil
-}
-
-func expandIncludes(line string) []string {
-	fields := strings.Fields(line)
-	if len(fields) < 2 {
-		return nil
-	}
-	paths := fields[1:]
-	var expanded []string
-	for _, p := range paths {
-		if strings.HasPrefix(p, "~") {
-			if home, err := os.UserHomeDir(); err == nil {
-				p = filepath.Join(home, p[1:])
-			}
-		}
-		matches, err := filepath.Glob(p)
-		if err == nil {
-			expanded = append(expanded, matches...)
-		}
-	}
-	return expanded
-}
-
 func NewSshConnect(app *App) *SshConnect {
 	var name = "SSH Connect"
 	tc := &SshConnect{